@@ -7,6 +7,7 @@ require (
 	github.com/aws/aws-sdk-go-v2 v1.36.3
 	github.com/aws/aws-sdk-go-v2/config v1.29.8
 	github.com/aws/aws-sdk-go-v2/service/ec2 v1.206.0
+	github.com/aws/aws-sdk-go-v2/service/resourcegroups v1.29.0
 	github.com/aws/aws-sdk-go-v2/service/ssm v1.57.0
 	github.com/aws/aws-sdk-go-v2/service/sts v1.33.16
 	github.com/hashicorp/terraform-plugin-framework v1.14.1
@@ -14,6 +15,7 @@ require (
 	github.com/hashicorp/terraform-plugin-go v0.26.0
 	github.com/hashicorp/terraform-plugin-sdk/v2 v2.36.1
 	github.com/hashicorp/terraform-plugin-testing v1.11.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -98,5 +100,4 @@ require (
 	google.golang.org/grpc v1.69.4 // indirect
 	google.golang.org/protobuf v1.36.3 // indirect
 	gopkg.in/yaml.v2 v2.3.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
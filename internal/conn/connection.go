@@ -6,15 +6,17 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/aws/arn"
 	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/resourcegroups"
 	"github.com/aws/aws-sdk-go-v2/service/ssm"
 	"github.com/aws/aws-sdk-go-v2/service/sts"
 )
 
 type AWSClient struct {
-	SSMClient *ssm.Client
-	AccountID string
-	Region    string
-	Partition string
+	SSMClient            *ssm.Client
+	ResourceGroupsClient *resourcegroups.Client
+	AccountID            string
+	Region               string
+	Partition            string
 }
 
 type AWSConfigOptions struct {
@@ -37,10 +39,11 @@ func CreateAWSClient(ctx context.Context, options *AWSConfigOptions) *AWSClient
 	accountId, partition, _ := getAccountIDAndPartition(ctx, cfg)
 
 	client := &AWSClient{
-		SSMClient: ssmClient,
-		AccountID: accountId,
-		Partition: partition,
-		Region:    cfg.Region,
+		SSMClient:            ssmClient,
+		ResourceGroupsClient: resourcegroups.NewFromConfig(cfg),
+		AccountID:            accountId,
+		Partition:            partition,
+		Region:               cfg.Region,
 	}
 
 	return client
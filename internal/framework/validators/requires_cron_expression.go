@@ -0,0 +1,96 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+	"github.com/coding-ia/terraform-provider-automation/internal/schedule"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"time"
+)
+
+var _ validator.Bool = RequiresCronExpressionValidator{}
+var _ validator.Int32 = RequiresCronExpressionValidator{}
+
+// RequiresCronExpressionValidator is the validator returned by RequiresCronExpression.
+type RequiresCronExpressionValidator struct {
+	expression path.Expression
+}
+
+// RequiresCronExpression ensures that an attribute is only set when the string attribute
+// at the given path expression holds a cron(...) schedule expression.  Boolean attributes
+// are only checked when they are true.
+func RequiresCronExpression(expression path.Expression) RequiresCronExpressionValidator {
+	return RequiresCronExpressionValidator{
+		expression: expression,
+	}
+}
+
+func (v RequiresCronExpressionValidator) Description(ctx context.Context) string {
+	return v.MarkdownDescription(ctx)
+}
+
+func (v RequiresCronExpressionValidator) MarkdownDescription(_ context.Context) string {
+	return fmt.Sprintf("Ensure that %q is set to a cron expression when this attribute is set", v.expression)
+}
+
+func (v RequiresCronExpressionValidator) ValidateBool(ctx context.Context, request validator.BoolRequest, response *validator.BoolResponse) {
+	if request.ConfigValue.IsNull() || request.ConfigValue.IsUnknown() || !request.ConfigValue.ValueBool() {
+		return
+	}
+
+	response.Diagnostics.Append(v.validate(ctx, request.Config, request.Path, request.PathExpression)...)
+}
+
+func (v RequiresCronExpressionValidator) ValidateInt32(ctx context.Context, request validator.Int32Request, response *validator.Int32Response) {
+	if request.ConfigValue.IsNull() || request.ConfigValue.IsUnknown() {
+		return
+	}
+
+	response.Diagnostics.Append(v.validate(ctx, request.Config, request.Path, request.PathExpression)...)
+}
+
+func (v RequiresCronExpressionValidator) validate(ctx context.Context, config tfsdk.Config, attributePath path.Path, attributePathExpression path.Expression) diag.Diagnostics {
+	matchedPaths, diags := config.PathMatches(ctx, attributePathExpression.Merge(v.expression))
+
+	if diags.HasError() {
+		return diags
+	}
+
+	for _, matchedPath := range matchedPaths {
+		var value types.String
+
+		diags.Append(config.GetAttribute(ctx, matchedPath, &value)...)
+
+		if diags.HasError() || value.IsUnknown() {
+			return diags
+		}
+
+		if value.IsNull() {
+			diags.AddAttributeError(
+				attributePath,
+				"Invalid Attribute Combination",
+				fmt.Sprintf("Attribute %q can only be specified when %q is a cron expression", attributePath, matchedPath),
+			)
+			continue
+		}
+
+		// Syntax errors are reported by the schedule expression attribute itself.
+		expression, err := schedule.Parse(value.ValueString())
+		if err != nil || expression.Kind() == schedule.KindCron {
+			continue
+		}
+
+		diags.AddAttributeError(
+			attributePath,
+			"Invalid Attribute Combination",
+			fmt.Sprintf("Attribute %q can only be specified when %q is a cron expression, not a %s expression.  %s would next fire at: %s",
+				attributePath, matchedPath, expression.Kind(), expression, schedule.FormatTimes(schedule.NextN(expression, time.Now(), scheduleFireTimes))),
+		)
+	}
+
+	return diags
+}
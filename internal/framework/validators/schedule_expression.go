@@ -0,0 +1,88 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+	"github.com/coding-ia/terraform-provider-automation/internal/schedule"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"time"
+)
+
+// scheduleFireTimes is the number of upcoming fire times reported in diagnostics.
+const scheduleFireTimes = 5
+
+var _ validator.String = scheduleExpressionValidator{}
+
+type scheduleExpressionValidator struct {
+	minimumInterval time.Duration
+}
+
+// ScheduleExpression ensures a string is a valid cron(...), rate(...) or at(...) schedule
+// expression that never fires more often than the minimum interval.  The next fire times
+// of valid cron and at expressions are reported in a warning, expressions that never fire
+// again only raise a warning.
+func ScheduleExpression(minimumInterval time.Duration) validator.String {
+	return scheduleExpressionValidator{
+		minimumInterval: minimumInterval,
+	}
+}
+
+func (v scheduleExpressionValidator) Description(ctx context.Context) string {
+	return v.MarkdownDescription(ctx)
+}
+
+func (v scheduleExpressionValidator) MarkdownDescription(_ context.Context) string {
+	return fmt.Sprintf("value must be a cron, rate or at expression firing at most every %s", v.minimumInterval)
+}
+
+func (v scheduleExpressionValidator) ValidateString(_ context.Context, request validator.StringRequest, response *validator.StringResponse) {
+	if request.ConfigValue.IsNull() || request.ConfigValue.IsUnknown() {
+		return
+	}
+
+	expression, err := schedule.Parse(request.ConfigValue.ValueString())
+	if err != nil {
+		response.Diagnostics.AddAttributeError(request.Path, "Invalid Schedule Expression", err.Error())
+		return
+	}
+
+	if rate, ok := expression.(*schedule.Rate); ok {
+		if rate.Interval < v.minimumInterval {
+			response.Diagnostics.AddAttributeError(
+				request.Path,
+				"Invalid Schedule Expression",
+				fmt.Sprintf("%s runs every %s, the minimum interval is %s.", expression, rate.Interval, v.minimumInterval),
+			)
+		}
+		return
+	}
+
+	// Expressions that already fired stay valid for existing resources, e.g. a one-time
+	// at(...) run in the past.
+	fireTimes := schedule.NextN(expression, time.Now(), scheduleFireTimes)
+	if len(fireTimes) == 0 {
+		response.Diagnostics.AddAttributeWarning(
+			request.Path,
+			"Schedule Expression In The Past",
+			fmt.Sprintf("%s never fires after the current time.", expression),
+		)
+		return
+	}
+
+	for i := 1; i < len(fireTimes); i++ {
+		if interval := fireTimes[i].Sub(fireTimes[i-1]); interval < v.minimumInterval {
+			response.Diagnostics.AddAttributeError(
+				request.Path,
+				"Invalid Schedule Expression",
+				fmt.Sprintf("%s fires %s apart, the minimum interval is %s.  Next fire times: %s", expression, interval, v.minimumInterval, schedule.FormatTimes(fireTimes)),
+			)
+			return
+		}
+	}
+
+	response.Diagnostics.AddAttributeWarning(
+		request.Path,
+		"Schedule Expression Fire Times",
+		fmt.Sprintf("%s fires next at: %s", expression, schedule.FormatTimes(fireTimes)),
+	)
+}
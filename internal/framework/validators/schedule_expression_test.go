@@ -0,0 +1,76 @@
+package validators
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"testing"
+	"time"
+)
+
+func TestScheduleExpression(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := map[string]struct {
+		value   types.String
+		errors  int
+		warning string
+	}{
+		"null":               {value: types.StringNull()},
+		"unknown":            {value: types.StringUnknown()},
+		"cron":               {value: types.StringValue("cron(0 2 ? * SUN *)"), warning: "Schedule Expression Fire Times"},
+		"rate":               {value: types.StringValue("rate(1 hour)")},
+		"at":                 {value: types.StringValue("at(2099-01-01T00:00:00)"), warning: "Schedule Expression Fire Times"},
+		"invalid":            {value: types.StringValue("every day"), errors: 1},
+		"rate too often":     {value: types.StringValue("rate(5 minutes)"), errors: 1},
+		"cron too often":     {value: types.StringValue("cron(0/5 * * * ? *)"), errors: 1},
+		"at in the past":     {value: types.StringValue("at(2020-01-01T00:00:00)"), warning: "Schedule Expression In The Past"},
+		"cron in the past":   {value: types.StringValue("cron(0 2 1 1 ? 2020)"), warning: "Schedule Expression In The Past"},
+		"cron until the end": {value: types.StringValue("cron(0 2 1 1 ? 2020-2099)"), warning: "Schedule Expression Fire Times"},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			request := validator.StringRequest{
+				Path:        path.Root("schedule_expression"),
+				ConfigValue: testCase.value,
+			}
+			var response validator.StringResponse
+
+			ScheduleExpression(30*time.Minute).ValidateString(ctx, request, &response)
+
+			if got := response.Diagnostics.ErrorsCount(); got != testCase.errors {
+				t.Errorf("expected %d errors, got %d: %v", testCase.errors, got, response.Diagnostics)
+			}
+
+			var warning string
+			if warnings := response.Diagnostics.Warnings(); len(warnings) > 0 {
+				warning = warnings[0].Summary()
+			}
+
+			if warning != testCase.warning {
+				t.Errorf("expected warning %q, got %v", testCase.warning, response.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestScheduleExpression_fireTimes(t *testing.T) {
+	request := validator.StringRequest{
+		Path:        path.Root("schedule_expression"),
+		ConfigValue: types.StringValue("at(2099-01-01T00:00:00)"),
+	}
+	var response validator.StringResponse
+
+	ScheduleExpression(30*time.Minute).ValidateString(context.Background(), request, &response)
+
+	warnings := response.Diagnostics.Warnings()
+	if len(warnings) != 1 {
+		t.Fatalf("expected a single warning, got %v", response.Diagnostics)
+	}
+
+	if want := "at(2099-01-01T00:00:00) fires next at: 2099-01-01T00:00:00Z"; warnings[0].Detail() != want {
+		t.Errorf("expected detail %q, got %q", want, warnings[0].Detail())
+	}
+}
@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awstypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"strings"
+)
+
+type AlarmConfigurationModel struct {
+	Alarms                 types.List `tfsdk:"alarms"`
+	IgnorePollAlarmFailure types.Bool `tfsdk:"ignore_poll_alarm_failure"`
+}
+
+func alarmConfigurationBlock(planModifiers ...planmodifier.List) schema.ListNestedBlock {
+	return schema.ListNestedBlock{
+		Description: "The CloudWatch alarms that stop the run when they enter the ALARM state.",
+		NestedObject: schema.NestedBlockObject{
+			Attributes: map[string]schema.Attribute{
+				"alarms": schema.ListAttribute{
+					Description: "The names of the CloudWatch alarms.",
+					Required:    true,
+					ElementType: types.StringType,
+					Validators: []validator.List{
+						listvalidator.SizeBetween(1, 1),
+						listvalidator.ValueStringsAre(stringvalidator.LengthBetween(1, 255)),
+					},
+				},
+				"ignore_poll_alarm_failure": schema.BoolAttribute{
+					Description: "Continue to run when the alarm status cannot be retrieved from CloudWatch.",
+					Optional:    true,
+					Computed:    true,
+					Default:     booldefault.StaticBool(false),
+				},
+			},
+		},
+		Validators: []validator.List{
+			listvalidator.SizeAtMost(1),
+		},
+		PlanModifiers: planModifiers,
+	}
+}
+
+func alarmConfigurationIn(ctx context.Context, alarmConfiguration []AlarmConfigurationModel) (*awstypes.AlarmConfiguration, diag.Diagnostics) {
+	if len(alarmConfiguration) == 0 {
+		return nil, nil
+	}
+
+	var names []string
+	diags := alarmConfiguration[0].Alarms.ElementsAs(ctx, &names, false)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	result := &awstypes.AlarmConfiguration{
+		IgnorePollAlarmFailure: alarmConfiguration[0].IgnorePollAlarmFailure.ValueBool(),
+	}
+
+	for _, name := range names {
+		result.Alarms = append(result.Alarms, awstypes.Alarm{
+			Name: aws.String(name),
+		})
+	}
+
+	return result, diags
+}
+
+func triggeredAlarmsOut(ctx context.Context, alarms []awstypes.AlarmStateInformation) types.List {
+	names := make([]string, 0, len(alarms))
+
+	for _, alarm := range alarms {
+		names = append(names, aws.ToString(alarm.Name))
+	}
+
+	listVal, _ := types.ListValueFrom(ctx, types.StringType, names)
+
+	return listVal
+}
+
+func appendTriggeredAlarmsDiagnostic(diags *diag.Diagnostics, alarms []awstypes.AlarmStateInformation) {
+	if len(alarms) == 0 {
+		return
+	}
+
+	states := make([]string, 0, len(alarms))
+	for _, alarm := range alarms {
+		states = append(states, fmt.Sprintf("%s (%s)", aws.ToString(alarm.Name), alarm.State))
+	}
+
+	diags.AddError("CloudWatch alarm triggered", fmt.Sprintf("The run was stopped by the following alarms: %s", strings.Join(states, ", ")))
+}
@@ -9,9 +9,10 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws/arn"
 	"github.com/aws/aws-sdk-go-v2/service/ssm"
 	awstypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/coding-ia/terraform-provider-automation/internal/framework/validators"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
-	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -22,38 +23,63 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"slices"
+	"strings"
 	"time"
 )
 
 var _ resource.Resource = &AWSSSMAssociationResource{}
 var _ resource.ResourceWithConfigure = &AWSSSMAssociationResource{}
+var _ resource.ResourceWithUpgradeState = &AWSSSMAssociationResource{}
+var _ resource.ResourceWithModifyPlan = &AWSSSMAssociationResource{}
+var _ resource.ResourceWithValidateConfig = &AWSSSMAssociationResource{}
 var _ resource.ResourceWithImportState = &AWSSSMAssociationResource{}
 
+// associationStateUpgradeSteps upgrades prior state one schema version at a time.  Append a
+// step for every schema change, the schema version is the number of steps.
+var associationStateUpgradeSteps = []stateUpgradeStep{
+	upgradeTargetsStateV0,
+	upgradeDocumentVersionSelectorStateV1,
+}
+
 type AWSSSMAssociationResource struct {
 	Meta Meta
 }
 
 type AWSSSMAssociationResourceModel struct {
-	ApplyOnlyAtCronInterval       types.Bool            `tfsdk:"apply_only_at_cron_interval"`
-	Arn                           types.String          `tfsdk:"arn"`
-	AssociationId                 types.String          `tfsdk:"association_id"`
-	AssociationName               types.String          `tfsdk:"association_name"`
-	AssociationVersion            types.String          `tfsdk:"association_version"`
-	AutomationTargetParameterName types.String          `tfsdk:"automation_target_parameter_name"`
-	ComplianceSeverity            types.String          `tfsdk:"compliance_severity"`
-	DocumentVersion               types.String          `tfsdk:"document_version"`
-	MaxConcurrency                types.String          `tfsdk:"max_concurrency"`
-	MaxErrors                     types.String          `tfsdk:"max_errors"`
-	Name                          types.String          `tfsdk:"name"`
-	OutputLocation                []OutputLocationModel `tfsdk:"output_location"`
-	Parameters                    types.Map             `tfsdk:"parameters"`
-	ScheduleExpression            types.String          `tfsdk:"schedule_expression"`
-	SyncCompliance                types.String          `tfsdk:"sync_compliance"`
-	Tags                          types.Map             `tfsdk:"tags"`
-	TagsAll                       types.Map             `tfsdk:"tags_all"`
-	Targets                       types.List            `tfsdk:"targets"`
-	WaitForSuccessTimeoutSeconds  types.Int32           `tfsdk:"wait_for_success_timeout_seconds"`
+	AlarmConfiguration            []AlarmConfigurationModel `tfsdk:"alarm_configuration"`
+	ApplyOnlyAtCronInterval       types.Bool                `tfsdk:"apply_only_at_cron_interval"`
+	Arn                           types.String              `tfsdk:"arn"`
+	AssociationId                 types.String              `tfsdk:"association_id"`
+	AssociationName               types.String              `tfsdk:"association_name"`
+	AssociationVersion            types.String              `tfsdk:"association_version"`
+	AutomationTargetParameterName types.String              `tfsdk:"automation_target_parameter_name"`
+	CalendarNames                 types.List                `tfsdk:"calendar_names"`
+	ComplianceSeverity            types.String              `tfsdk:"compliance_severity"`
+	DocumentVersion               types.String              `tfsdk:"document_version"`
+	DocumentVersionSelector       types.String              `tfsdk:"document_version_selector"`
+	Duration                      types.Int32               `tfsdk:"duration"`
+	EffectiveParameters           ParametersValue           `tfsdk:"effective_parameters"`
+	MaxConcurrency                types.String              `tfsdk:"max_concurrency"`
+	MaxErrors                     types.String              `tfsdk:"max_errors"`
+	Name                          types.String              `tfsdk:"name"`
+	OutputLocation                []OutputLocationModel     `tfsdk:"output_location"`
+	Parameters                    ParametersValue           `tfsdk:"parameters"`
+	RunNowTriggers                types.Map                 `tfsdk:"run_now_triggers"`
+	ScheduleOffset                types.Int32               `tfsdk:"schedule_offset"`
+	ScheduleExpression            types.String              `tfsdk:"schedule_expression"`
+	SensitiveParameters           ParametersValue           `tfsdk:"sensitive_parameters"`
+	SensitiveParametersVersion    types.Int32               `tfsdk:"sensitive_parameters_version"`
+	SyncCompliance                types.String              `tfsdk:"sync_compliance"`
+	Tags                          types.Map                 `tfsdk:"tags"`
+	TagsAll                       types.Map                 `tfsdk:"tags_all"`
+	Targets                       TargetsValue              `tfsdk:"targets"`
+	TriggeredAlarms               types.List                `tfsdk:"triggered_alarms"`
+	WaitFor                       types.String              `tfsdk:"wait_for"`
+	WaitForSuccessPercentage      types.Int32               `tfsdk:"wait_for_success_percentage"`
+	WaitForSuccessTimeoutSeconds  types.Int32               `tfsdk:"wait_for_success_timeout_seconds"`
 }
 
 type OutputLocationModel struct {
@@ -62,6 +88,38 @@ type OutputLocationModel struct {
 	S3Region     types.String `tfsdk:"s3_region"`
 }
 
+const (
+	associationStatusCalendarClosed = "CalendarClosed"
+
+	// The states while waiting for a run started by run_now_triggers to create an execution.
+	associationExecutionStatusNotStarted = "NotStarted"
+	associationExecutionStatusStarted    = "Started"
+
+	// associationMinimumScheduleInterval is the most often State Manager runs an association.
+	associationMinimumScheduleInterval = 30 * time.Minute
+
+	// associationMinimumWaitTimeout leaves every wait for a run time to check its status
+	// once, when the shared deadline has already passed.
+	associationMinimumWaitTimeout = 10 * time.Second
+
+	associationImportPrefixName     = "name:"
+	associationImportPrefixDocument = "document:"
+
+	// The wait_for modes, how the targets of a new association have to complete.
+	associationWaitForOverview   = "overview"
+	associationWaitForAllTargets = "all_targets"
+	associationWaitForPercentage = "percentage"
+)
+
+// associationProviderAttributes are only used by the provider and never sent to AWS, so
+// changing them does not update the association.
+var associationProviderAttributes = []string{
+	"run_now_triggers",
+	"wait_for",
+	"wait_for_success_percentage",
+	"wait_for_success_timeout_seconds",
+}
+
 func newAWSSSMAssociationResource() resource.Resource {
 	return &AWSSSMAssociationResource{}
 }
@@ -80,6 +138,7 @@ func (a *AWSSSMAssociationResource) Metadata(_ context.Context, request resource
 
 func (a *AWSSSMAssociationResource) Schema(_ context.Context, _ resource.SchemaRequest, response *resource.SchemaResponse) {
 	response.Schema = schema.Schema{
+		Version:             int64(len(associationStateUpgradeSteps)),
 		MarkdownDescription: "Associates an SSM Document to an instance or EC2 tag.  This resource is intended to address the issues that exist in the official AWS provider.",
 		Attributes: map[string]schema.Attribute{
 			"apply_only_at_cron_interval": schema.BoolAttribute{
@@ -87,6 +146,9 @@ func (a *AWSSSMAssociationResource) Schema(_ context.Context, _ resource.SchemaR
 				Optional:    true,
 				Computed:    true,
 				Default:     booldefault.StaticBool(false),
+				Validators: []validator.Bool{
+					validators.RequiresCronExpression(path.MatchRoot("schedule_expression")),
+				},
 			},
 			"arn": schema.StringAttribute{
 				Description: "The ARN of the SSM Association.",
@@ -120,6 +182,20 @@ func (a *AWSSSMAssociationResource) Schema(_ context.Context, _ resource.SchemaR
 					stringvalidator.LengthBetween(1, 50),
 				},
 			},
+			"calendar_names": schema.ListAttribute{
+				Description: "The names or Amazon Resource Names (ARNs) of the Change Calendar type documents the association is gated under.  The association only runs when all calendars are OPEN.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ValueStringsAre(
+						stringvalidator.Any(
+							stringvalidator.RegexMatches(regexache.MustCompile(`^[0-9A-Za-z_.-]{3,128}$`), "must be a valid document name"),
+							stringvalidator.RegexMatches(regexache.MustCompile(`^arn:aws[0-9a-z-]*:ssm:[0-9a-z-]+:\d{12}:document/[0-9A-Za-z_.-]{3,128}$`), "must be a valid document ARN"),
+						),
+					),
+				},
+			},
 			"compliance_severity": schema.StringAttribute{
 				Description: "The severity level to assign to the association.",
 				Optional:    true,
@@ -135,12 +211,20 @@ func (a *AWSSSMAssociationResource) Schema(_ context.Context, _ resource.SchemaR
 					),
 				},
 			},
-			"document_version": schema.StringAttribute{
-				Description: "The document version you want to associate with the targets.  Can be a specific version or the default version.",
-				Optional:    true,
+			"document_version":          documentVersionAttribute("The document version you want to associate with the targets."),
+			"document_version_selector": documentVersionSelectorAttribute(),
+			"effective_parameters": schema.MapAttribute{
+				Description: "The parameters the association runs with: the configured parameters and the default values of the document parameters that are not configured.",
 				Computed:    true,
-				Validators: []validator.String{
-					stringvalidator.RegexMatches(regexache.MustCompile(`^([$]LATEST|[$]DEFAULT|^[1-9][0-9]*$)$`), ""),
+				CustomType:  NewParametersType(),
+				ElementType: types.ListType{ElemType: types.StringType},
+			},
+			"duration": schema.Int32Attribute{
+				Description: "The number of hours the association runs on its targets before it is stopped.  Only valid with a cron schedule_expression.",
+				Optional:    true,
+				Validators: []validator.Int32{
+					int32validator.Between(1, 24),
+					validators.RequiresCronExpression(path.MatchRoot("schedule_expression")),
 				},
 			},
 			"max_concurrency": schema.StringAttribute{
@@ -165,18 +249,35 @@ func (a *AWSSSMAssociationResource) Schema(_ context.Context, _ resource.SchemaR
 				},
 			},
 			"parameters": schema.MapAttribute{
-				Description: "The parameters for the runtime configuration of the document.",
+				Description: "The parameters for the runtime configuration of the document.  Not read for imported associations until the next apply updates the association, as any of them may have been set through sensitive_parameters.  Declare both after importing.",
 				Optional:    true,
 				Computed:    true,
+				CustomType:  NewParametersType(),
 				ElementType: types.ListType{ElemType: types.StringType},
 			},
 			"schedule_expression": schema.StringAttribute{
-				Description: "A cron expression when the association will be applied to the targets.",
+				Description: "A cron or rate expression when the association will be applied to the targets.  The next fire times of cron and at expressions are reported in a warning at plan time.",
 				Optional:    true,
 				Validators: []validator.String{
 					stringvalidator.LengthBetween(1, 256),
+					validators.ScheduleExpression(associationMinimumScheduleInterval),
 				},
 			},
+			"run_now_triggers": schema.MapAttribute{
+				MarkdownDescription: "Arbitrary values that run the association on all targets immediately when they change, e.g. a timestamp or the ID of a fleet refresh.  The run does not create a new association version, and when other changes create one the run of the new version is used instead.  Set `wait_for_success_timeout_seconds` to wait for the run.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"schedule_offset": schema.Int32Attribute{
+				Description: "The number of days to wait after the scheduled day to run the association.  Only valid with a cron schedule_expression.",
+				Optional:    true,
+				Validators: []validator.Int32{
+					int32validator.Between(1, 6),
+					validators.RequiresCronExpression(path.MatchRoot("schedule_expression")),
+				},
+			},
+			"sensitive_parameters":         sensitiveParametersAttribute(),
+			"sensitive_parameters_version": sensitiveParametersVersionAttribute(),
 			"sync_compliance": schema.StringAttribute{
 				Description: "The mode for generating association compliance. You can specify AUTO or MANUAL. In AUTO mode, the system uses the status of the association execution to determine the compliance status.  In MANUAL mode, you must specify the AssociationId as a parameter.",
 				Optional:    true,
@@ -200,18 +301,41 @@ func (a *AWSSSMAssociationResource) Schema(_ context.Context, _ resource.SchemaR
 					mapplanmodifier.UseStateForUnknown(),
 				},
 			},
-			"targets": schema.ListAttribute{
-				Description: "The targets for the association.  You can target managed nodes by using tags, AWS resource groups, all managed nodes in an AWS account, or individual managed node IDs.",
-				Optional:    true,
+			"targets": schema.ListNestedAttribute{
+				Description:  "The targets for the association.  You can target managed nodes by using tags, AWS resource groups, all managed nodes in an AWS account, or individual managed node IDs.  Leave unset rather than empty for Automation runbooks without targets.",
+				Optional:     true,
+				Computed:     true,
+				CustomType:   NewTargetsType(),
+				NestedObject: targetNestedObject(),
+				Validators: []validator.List{
+					listvalidator.SizeBetween(1, 5),
+				},
+				PlanModifiers: []planmodifier.List{
+					useNoOpAutomationTargets(),
+				},
+			},
+			"triggered_alarms": schema.ListAttribute{
+				Description: "The CloudWatch alarms that were invoked by the association.",
 				Computed:    true,
-				ElementType: types.ObjectType{
-					AttrTypes: map[string]attr.Type{
-						"key":    types.StringType,
-						"values": types.ListType{ElemType: types.StringType},
-					},
+				ElementType: types.StringType,
+			},
+			"wait_for": schema.StringAttribute{
+				MarkdownDescription: "How the targets have to complete when waiting for a new association or a run started by `run_now_triggers`, see `wait_for_success_timeout_seconds`.  `overview` (the default) waits for the overview status of the association, which succeeds as soon as some targets succeed.  `all_targets` waits until every target succeeded and fails as soon as a target did not succeed.  `percentage` waits until `wait_for_success_percentage` of the targets succeeded and fails once that can no longer be reached.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						associationWaitForOverview,
+						associationWaitForAllTargets,
+						associationWaitForPercentage,
+					),
+					stringvalidator.AlsoRequires(path.MatchRoot("wait_for_success_timeout_seconds")),
 				},
-				Validators: []validator.List{
-					listvalidator.SizeAtMost(5),
+			},
+			"wait_for_success_percentage": schema.Int32Attribute{
+				MarkdownDescription: "The percentage of targets that have to succeed when `wait_for` is `percentage`.",
+				Optional:            true,
+				Validators: []validator.Int32{
+					int32validator.Between(1, 100),
 				},
 			},
 			"wait_for_success_timeout_seconds": schema.Int32Attribute{
@@ -219,6 +343,7 @@ func (a *AWSSSMAssociationResource) Schema(_ context.Context, _ resource.SchemaR
 			},
 		},
 		Blocks: map[string]schema.Block{
+			"alarm_configuration": alarmConfigurationBlock(),
 			"output_location": schema.ListNestedBlock{
 				Description: "An Amazon Simple Storage Service (Amazon S3) bucket where you want to store the output details of the request.",
 				NestedObject: schema.NestedBlockObject{
@@ -254,18 +379,121 @@ func (a *AWSSSMAssociationResource) Schema(_ context.Context, _ resource.SchemaR
 	}
 }
 
+func (a *AWSSSMAssociationResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return stateUpgraders(associationStateUpgradeSteps...)
+}
+
+// ValidateConfig ensures that wait_for_success_percentage is set exactly when waiting for
+// a percentage of the targets.
+func (a *AWSSSMAssociationResource) ValidateConfig(ctx context.Context, request resource.ValidateConfigRequest, response *resource.ValidateConfigResponse) {
+	var waitFor types.String
+	var percentage types.Int32
+
+	response.Diagnostics.Append(request.Config.GetAttribute(ctx, path.Root("wait_for"), &waitFor)...)
+	response.Diagnostics.Append(request.Config.GetAttribute(ctx, path.Root("wait_for_success_percentage"), &percentage)...)
+
+	if response.Diagnostics.HasError() || waitFor.IsUnknown() || percentage.IsUnknown() {
+		return
+	}
+
+	switch {
+	case waitFor.ValueString() == associationWaitForPercentage && percentage.IsNull():
+		response.Diagnostics.AddAttributeError(
+			path.Root("wait_for_success_percentage"),
+			"Missing Attribute Configuration",
+			fmt.Sprintf("Attribute %q must be specified when %q is %q", path.Root("wait_for_success_percentage"), path.Root("wait_for"), associationWaitForPercentage),
+		)
+	case waitFor.ValueString() != associationWaitForPercentage && !percentage.IsNull():
+		response.Diagnostics.AddAttributeError(
+			path.Root("wait_for_success_percentage"),
+			"Invalid Attribute Combination",
+			fmt.Sprintf("Attribute %q can only be specified when %q is %q", path.Root("wait_for_success_percentage"), path.Root("wait_for"), associationWaitForPercentage),
+		)
+	}
+}
+
+// ModifyPlan validates the configured parameters against the parameters of the document
+// and the Parameter Store parameters they reference, and plans effective_parameters, the
+// parameters the association runs with including the document defaults for parameters
+// that are not configured.  Sensitive parameters are left out of effective_parameters.
+func (a *AWSSSMAssociationResource) ModifyPlan(ctx context.Context, request resource.ModifyPlanRequest, response *resource.ModifyPlanResponse) {
+	// Nothing to validate when destroying.
+	if request.Plan.Raw.IsNull() {
+		return
+	}
+
+	var config AWSSSMAssociationResourceModel
+
+	response.Diagnostics.Append(request.Config.Get(ctx, &config)...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	response.Diagnostics.Append(validateSensitiveParameters(config.Parameters, config.SensitiveParameters)...)
+	response.Diagnostics.Append(validateParameterReferences(ctx, a.Meta.AWSClient.SSMClient, config.Parameters, config.SensitiveParameters)...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	document, diags := findPlanDocument(ctx, a.Meta.AWSClient.SSMClient, config.Name, planDocumentVersion(config.DocumentVersion, config.DocumentVersionSelector))
+	response.Diagnostics.Append(diags...)
+
+	if document == nil {
+		return
+	}
+
+	response.Diagnostics.Append(validateDocumentParameters(ctx, document, config.Name.ValueString(), config.Parameters, config.SensitiveParameters, config.AutomationTargetParameterName)...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	// Unconfigured parameters are computed from what AWS returns, defaults can only be
+	// added once they are known.
+	var parameters ParametersValue
+
+	response.Diagnostics.Append(response.Plan.GetAttribute(ctx, path.Root("parameters"), &parameters)...)
+
+	if response.Diagnostics.HasError() || parameters.IsUnknown() {
+		return
+	}
+
+	exclude := append(sensitiveParameterKeys(config.SensitiveParameters), config.AutomationTargetParameterName.ValueString())
+
+	effective, diags := withDocumentDefaults(ctx, document, parameters, exclude...)
+	response.Diagnostics.Append(diags...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	response.Diagnostics.Append(response.Plan.SetAttribute(ctx, path.Root("effective_parameters"), effective)...)
+}
+
 func (a *AWSSSMAssociationResource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
 	var data AWSSSMAssociationResourceModel
+	var sensitive ParametersValue
 
 	response.Diagnostics.Append(request.Plan.Get(ctx, &data)...)
+	response.Diagnostics.Append(request.Config.GetAttribute(ctx, path.Root("sensitive_parameters"), &sensitive)...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	alarmConfiguration, diags := alarmConfigurationIn(ctx, data.AlarmConfiguration)
+	response.Diagnostics.Append(diags...)
 
 	if response.Diagnostics.HasError() {
 		return
 	}
 
 	input := &ssm.CreateAssociationInput{
-		Name: aws.String(data.Name.ValueString()),
-		Tags: tagsIn(data.Tags.Elements()),
+		AlarmConfiguration: alarmConfiguration,
+		Name:               aws.String(data.Name.ValueString()),
+		Tags:               tagsIn(data.Tags.Elements()),
 	}
 
 	if !data.ApplyOnlyAtCronInterval.IsNull() {
@@ -280,12 +508,22 @@ func (a *AWSSSMAssociationResource) Create(ctx context.Context, request resource
 		input.AutomationTargetParameterName = data.AutomationTargetParameterName.ValueStringPointer()
 	}
 
+	if !data.CalendarNames.IsNull() {
+		response.Diagnostics.Append(data.CalendarNames.ElementsAs(ctx, &input.CalendarNames, false)...)
+
+		if response.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	if !data.ComplianceSeverity.IsNull() {
 		input.ComplianceSeverity = awstypes.AssociationComplianceSeverity(data.ComplianceSeverity.ValueString())
 	}
 
-	if data.DocumentVersion.ValueString() != "" {
-		input.DocumentVersion = data.DocumentVersion.ValueStringPointer()
+	input.DocumentVersion = documentVersionIn(data.DocumentVersion, data.DocumentVersionSelector)
+
+	if !data.Duration.IsNull() {
+		input.Duration = data.Duration.ValueInt32Pointer()
 	}
 
 	if !data.MaxConcurrency.IsNull() {
@@ -322,18 +560,29 @@ func (a *AWSSSMAssociationResource) Create(ctx context.Context, request resource
 		input.Parameters = parametersIn(ctx, data.Parameters.Elements())
 	}
 
+	input.Parameters = withSensitiveParameters(ctx, input.Parameters, sensitive)
+
 	if !data.ScheduleExpression.IsNull() {
 		input.ScheduleExpression = data.ScheduleExpression.ValueStringPointer()
 	}
 
+	if !data.ScheduleOffset.IsNull() {
+		input.ScheduleOffset = data.ScheduleOffset.ValueInt32Pointer()
+	}
+
 	if !data.SyncCompliance.IsNull() {
 		input.SyncCompliance = awstypes.AssociationSyncCompliance(data.SyncCompliance.ValueString())
 	}
 
-	if !data.Targets.IsUnknown() && !data.Targets.IsNull() {
-		input.Targets = targetsIn(data.Targets)
+	targets, diags := targetsIn(ctx, data.Targets.ListValue)
+	response.Diagnostics.Append(diags...)
+
+	if response.Diagnostics.HasError() {
+		return
 	}
 
+	input.Targets = targets
+
 	ssmClient := a.Meta.AWSClient.SSMClient
 	output, err := ssmClient.CreateAssociation(ctx, input)
 	if err != nil {
@@ -359,19 +608,37 @@ func (a *AWSSSMAssociationResource) Create(ctx context.Context, request resource
 	SetFrameworkFromStringPointer(&data.AssociationId, output.AssociationDescription.AssociationId)
 	SetFrameworkFromStringPointer(&data.AssociationName, output.AssociationDescription.AssociationName)
 	SetFrameworkFromStringPointer(&data.AssociationVersion, output.AssociationDescription.AssociationVersion)
-	SetFrameworkFromStringPointer(&data.DocumentVersion, output.AssociationDescription.DocumentVersion)
+	data.DocumentVersion, data.DocumentVersionSelector, err = documentVersionOut(ctx, ssmClient, data.Name.ValueString(), aws.ToString(output.AssociationDescription.DocumentVersion), data.DocumentVersion)
+	if err != nil {
+		response.Diagnostics.Append(documentVersionWarning(err))
+	}
 	SetFrameworkTags(&data.TagsAll, input.Tags, true)
-	data.Targets = targetsOut(ctx, output.AssociationDescription.Targets)
+	data.Targets, diags = targetsOut(ctx, output.AssociationDescription.Targets)
+	response.Diagnostics.Append(diags...)
+	data.TriggeredAlarms = triggeredAlarmsOut(ctx, output.AssociationDescription.TriggeredAlarms)
+
+	sensitiveKeys := sensitiveParameterKeys(sensitive)
+	response.Diagnostics.Append(setSensitiveParameterKeys(ctx, response.Private, sensitiveKeys)...)
 
 	if data.Parameters.IsNull() || data.Parameters.IsUnknown() {
-		data.Parameters = parametersOut(output.AssociationDescription.Parameters)
+		data.Parameters = parametersOut(withoutSensitiveParameters(output.AssociationDescription.Parameters, sensitiveKeys))
+	}
+
+	if data.EffectiveParameters.IsUnknown() {
+		exclude := append(sensitiveKeys, data.AutomationTargetParameterName.ValueString())
+		data.EffectiveParameters, diags = effectiveParameters(ctx, ssmClient, data.Name.ValueString(), data.DocumentVersion.ValueString(), data.Parameters, exclude...)
+		response.Diagnostics.Append(diags...)
 	}
 
 	if !data.WaitForSuccessTimeoutSeconds.IsNull() &&
 		!data.WaitForSuccessTimeoutSeconds.IsUnknown() {
 		timeout := time.Duration(data.WaitForSuccessTimeoutSeconds.ValueInt32()) * time.Second
 		associationId := aws.ToString(output.AssociationDescription.AssociationId)
-		if _, err := waitAssociationCreated(ctx, ssmClient, associationId, timeout, response.Diagnostics); err != nil {
+		association, err := waitAssociationCreated(ctx, ssmClient, associationId, timeout, data.WaitFor.ValueString(), data.WaitForSuccessPercentage.ValueInt32(), &response.Diagnostics)
+		if association != nil {
+			data.TriggeredAlarms = triggeredAlarmsOut(ctx, association.TriggeredAlarms)
+		}
+		if err != nil {
 			response.Diagnostics.AddError("Error creating SSM association", fmt.Sprintf("waiting for SSM Association (%s) create: %s", associationId, err.Error()))
 			return
 		}
@@ -394,6 +661,15 @@ func (a *AWSSSMAssociationResource) Read(ctx context.Context, request resource.R
 		response.Diagnostics.AddError("Error reading association", err.Error())
 		return
 	}
+
+	sensitiveKeys, sensitiveKeysKnown, diags := getSensitiveParameterKeys(ctx, request.Private)
+	response.Diagnostics.Append(diags...)
+
+	// Effective parameters not set on the association are document defaults.
+	defaults := data.EffectiveParameters
+	if !defaults.IsNull() && !defaults.IsUnknown() {
+		defaults = withoutParameters(defaults, data.Parameters)
+	}
 	tags, err := findAssociationTagsByID(ctx, a.Meta.AWSClient.SSMClient, data.AssociationId.ValueString())
 	if err != nil {
 		response.Diagnostics.AddError("Error reading association tags", err.Error())
@@ -406,12 +682,20 @@ func (a *AWSSSMAssociationResource) Read(ctx context.Context, request resource.R
 
 	SetFrameworkFromStringPointer(&data.AssociationName, association.AssociationName)
 	SetFrameworkFromStringPointer(&data.AutomationTargetParameterName, association.AutomationTargetParameterName)
+	response.Diagnostics.Append(SetFrameworkFromStringList(ctx, &data.CalendarNames, association.CalendarNames)...)
+	SetFrameworkFromInt32Pointer(&data.Duration, association.Duration)
 	SetFrameworkFromStringPointer(&data.MaxConcurrency, association.MaxConcurrency)
 	SetFrameworkFromStringPointer(&data.MaxErrors, association.MaxErrors)
 	SetFrameworkFromStringPointer(&data.Name, association.Name)
 	SetFrameworkFromStringPointer(&data.ScheduleExpression, association.ScheduleExpression)
+	SetFrameworkFromInt32Pointer(&data.ScheduleOffset, association.ScheduleOffset)
 
 	SetFrameworkFromOutputLocationModel(&data.OutputLocation, association.OutputLocation)
+	response.Diagnostics.Append(SetFrameworkFromAlarmConfigurationModel(ctx, &data.AlarmConfiguration, association.AlarmConfiguration)...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
 
 	// computed
 	amazonResourceName := arn.ARN{
@@ -426,10 +710,35 @@ func (a *AWSSSMAssociationResource) Read(ctx context.Context, request resource.R
 	SetFrameworkFromString(&data.Arn, amazonResourceName, false)
 	SetFrameworkFromStringPointer(&data.AssociationId, association.AssociationId)
 	SetFrameworkFromStringPointer(&data.AssociationVersion, association.AssociationVersion)
-	SetFrameworkFromStringPointer(&data.DocumentVersion, association.DocumentVersion)
-	data.Parameters = parametersOut(association.Parameters)
+	data.DocumentVersion, data.DocumentVersionSelector, err = documentVersionOut(ctx, a.Meta.AWSClient.SSMClient, data.Name.ValueString(), aws.ToString(association.DocumentVersion), data.DocumentVersion)
+	if err != nil {
+		response.Diagnostics.Append(documentVersionWarning(err))
+	}
+	data.Parameters = parametersOut(withoutSensitiveParameters(association.Parameters, sensitiveKeys))
+	// Any parameter of an imported association may have been set through
+	// sensitive_parameters, none is read until the next apply records which are.
+	if !sensitiveKeysKnown {
+		data.Parameters = NewParametersValueNull()
+		if len(association.Parameters) > 0 {
+			response.Diagnostics.AddWarning(
+				"Association parameters not read",
+				fmt.Sprintf("The parameters of SSM Association (%s) are not read into state, as they may contain sensitive parameters.  Declare parameters and sensitive_parameters in the configuration, they are read again after the next apply.", data.AssociationId.ValueString()),
+			)
+		}
+	}
 	SetFrameworkTags(&data.TagsAll, tags, true)
-	data.Targets = targetsOut(ctx, association.Targets)
+
+	// Imported associations have no defaults in state yet.
+	if defaults.IsNull() || defaults.IsUnknown() {
+		exclude := append(sensitiveKeys, data.AutomationTargetParameterName.ValueString())
+		data.EffectiveParameters, diags = effectiveParameters(ctx, a.Meta.AWSClient.SSMClient, data.Name.ValueString(), data.DocumentVersion.ValueString(), data.Parameters, exclude...)
+	} else {
+		data.EffectiveParameters, diags = mergeParameters(data.Parameters, defaults)
+	}
+	response.Diagnostics.Append(diags...)
+	data.Targets, diags = targetsOut(ctx, association.Targets)
+	response.Diagnostics.Append(diags...)
+	data.TriggeredAlarms = triggeredAlarmsOut(ctx, association.TriggeredAlarms)
 
 	response.Diagnostics.Append(response.State.Set(ctx, &data)...)
 }
@@ -444,8 +753,72 @@ func (a *AWSSSMAssociationResource) Update(ctx context.Context, request resource
 		return
 	}
 
+	definitionChanged, err := associationDefinitionChanged(request.Plan.Raw, request.State.Raw)
+	if err != nil {
+		response.Diagnostics.AddError("Error updating association", err.Error())
+		return
+	}
+
+	conn := a.Meta.AWSClient.SSMClient
+	runNow := !plan.RunNowTriggers.Equal(state.RunNowTriggers) && len(plan.RunNowTriggers.Elements()) > 0
+
+	// A new association version already runs on all targets, only wait for that run.
+	runsOnUpdate := definitionChanged && !plan.ApplyOnlyAtCronInterval.ValueBool()
+
+	var previous *awstypes.AssociationExecution
+	if runNow && runsOnUpdate && !plan.WaitForSuccessTimeoutSeconds.IsNull() {
+		previous, err = findLatestAssociationExecution(ctx, conn, state.AssociationId.ValueString())
+		if err != nil {
+			response.Diagnostics.AddError("Error updating association", err.Error())
+			return
+		}
+	}
+
+	started := time.Now()
+
+	if definitionChanged {
+		response.Diagnostics.Append(a.updateAssociation(ctx, request, response.Private, &plan, state)...)
+	} else {
+		// Nothing to send to AWS, keep the association as it is in state.
+		plan = withAssociationProviderAttributes(state, plan)
+	}
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	if runNow {
+		var association *awstypes.AssociationDescription
+		if runsOnUpdate {
+			association, err = waitAssociationRun(ctx, conn, plan, previous, started, &response.Diagnostics)
+		} else {
+			association, err = startAssociationOnce(ctx, conn, plan, &response.Diagnostics)
+		}
+		if association != nil {
+			plan.TriggeredAlarms = triggeredAlarmsOut(ctx, association.TriggeredAlarms)
+		}
+		if err != nil {
+			// The association is updated, keep it in state.
+			response.Diagnostics.Append(response.State.Set(ctx, &plan)...)
+			response.Diagnostics.AddError("Error running SSM association", fmt.Sprintf("running SSM Association (%s): %s", plan.AssociationId.ValueString(), err.Error()))
+			return
+		}
+	}
+
+	response.Diagnostics.Append(response.State.Set(ctx, &plan)...)
+}
+
+// updateAssociation sends the plan to AWS, which creates a new association version, and
+// sets the computed attributes of the plan.
+func (a *AWSSSMAssociationResource) updateAssociation(ctx context.Context, request resource.UpdateRequest, private privateState, plan *AWSSSMAssociationResourceModel, state AWSSSMAssociationResourceModel) diag.Diagnostics {
+	alarmConfiguration, diags := alarmConfigurationIn(ctx, plan.AlarmConfiguration)
+	if diags.HasError() {
+		return diags
+	}
+
 	input := &ssm.UpdateAssociationInput{
-		AssociationId: state.AssociationId.ValueStringPointer(),
+		AlarmConfiguration: alarmConfiguration,
+		AssociationId:      state.AssociationId.ValueStringPointer(),
 	}
 
 	if !plan.ApplyOnlyAtCronInterval.IsNull() {
@@ -460,15 +833,31 @@ func (a *AWSSSMAssociationResource) Update(ctx context.Context, request resource
 		input.AutomationTargetParameterName = plan.AutomationTargetParameterName.ValueStringPointer()
 	}
 
+	if !plan.CalendarNames.IsNull() {
+		diags.Append(plan.CalendarNames.ElementsAs(ctx, &input.CalendarNames, false)...)
+
+		if diags.HasError() {
+			return diags
+		}
+	} else if !state.CalendarNames.IsNull() {
+		input.CalendarNames = []string{}
+	}
+
 	if !plan.ComplianceSeverity.IsNull() {
 		input.ComplianceSeverity = awstypes.AssociationComplianceSeverity(plan.ComplianceSeverity.ValueString())
 	}
 
-	if !plan.DocumentVersion.IsNull() &&
-		plan.DocumentVersion.ValueString() != "" {
-		input.DocumentVersion = plan.DocumentVersion.ValueStringPointer()
-	} else {
-		plan.DocumentVersion = state.DocumentVersion
+	// Unconfigured versions are unknown, keep the version or selector the association has.
+	input.DocumentVersion = documentVersionIn(plan.DocumentVersion, plan.DocumentVersionSelector)
+	if input.DocumentVersion == nil {
+		input.DocumentVersion = documentVersionIn(types.StringNull(), state.DocumentVersionSelector)
+	}
+	if input.DocumentVersion == nil {
+		input.DocumentVersion = state.DocumentVersion.ValueStringPointer()
+	}
+
+	if !plan.Duration.IsNull() {
+		input.Duration = plan.Duration.ValueInt32Pointer()
 	}
 
 	if !plan.MaxConcurrency.IsNull() {
@@ -505,23 +894,46 @@ func (a *AWSSSMAssociationResource) Update(ctx context.Context, request resource
 		input.Parameters = parametersIn(ctx, plan.Parameters.Elements())
 	}
 
+	// UpdateAssociation replaces all parameters, so sensitive parameters are always sent.
+	var sensitive ParametersValue
+
+	diags.Append(request.Config.GetAttribute(ctx, path.Root("sensitive_parameters"), &sensitive)...)
+
+	if diags.HasError() {
+		return diags
+	}
+
+	input.Parameters = withSensitiveParameters(ctx, input.Parameters, sensitive)
+	sensitiveKeys := sensitiveParameterKeys(sensitive)
+
 	if !plan.ScheduleExpression.IsNull() {
 		input.ScheduleExpression = plan.ScheduleExpression.ValueStringPointer()
 	}
 
+	if !plan.ScheduleOffset.IsNull() {
+		input.ScheduleOffset = plan.ScheduleOffset.ValueInt32Pointer()
+	}
+
 	if !plan.SyncCompliance.IsNull() {
 		input.SyncCompliance = awstypes.AssociationSyncCompliance(plan.SyncCompliance.ValueString())
 	}
 
-	stateTargets := targetsIn(state.Targets)
-	if !isAutoSSMTarget(stateTargets) {
-		input.Targets = targetsIn(plan.Targets)
+	// The NoOp target is generated by AWS and is never sent back.
+	planTargets, d := targetsIn(ctx, plan.Targets.ListValue)
+	diags.Append(d...)
+
+	if diags.HasError() {
+		return diags
+	}
+
+	if len(planTargets) > 0 && !isAutoSSMTarget(planTargets) {
+		input.Targets = planTargets
 	}
 
 	output, err := a.Meta.AWSClient.SSMClient.UpdateAssociation(ctx, input)
 	if err != nil {
-		response.Diagnostics.AddError("Error updating association", err.Error())
-		return
+		diags.AddError("Error updating association", err.Error())
+		return diags
 	}
 	if output != nil {
 		amazonResourceName := arn.ARN{
@@ -535,13 +947,141 @@ func (a *AWSSSMAssociationResource) Update(ctx context.Context, request resource
 		SetFrameworkFromString(&plan.Arn, amazonResourceName, false)
 		SetFrameworkFromStringPointer(&plan.AssociationId, output.AssociationDescription.AssociationId)
 		SetFrameworkFromStringPointer(&plan.AssociationVersion, output.AssociationDescription.AssociationVersion)
-		SetFrameworkFromStringPointer(&plan.DocumentVersion, output.AssociationDescription.DocumentVersion)
-		plan.Parameters = parametersOut(output.AssociationDescription.Parameters)
-		plan.Targets = targetsOut(ctx, output.AssociationDescription.Targets)
+		plan.DocumentVersion, plan.DocumentVersionSelector, err = documentVersionOut(ctx, a.Meta.AWSClient.SSMClient, plan.Name.ValueString(), aws.ToString(output.AssociationDescription.DocumentVersion), plan.DocumentVersion)
+		if err != nil {
+			diags.Append(documentVersionWarning(err))
+		}
+		plan.Parameters = parametersOut(withoutSensitiveParameters(output.AssociationDescription.Parameters, sensitiveKeys))
+		plan.Targets, d = targetsOut(ctx, output.AssociationDescription.Targets)
+		diags.Append(d...)
+
+		if plan.EffectiveParameters.IsUnknown() {
+			exclude := append(sensitiveKeys, plan.AutomationTargetParameterName.ValueString())
+			plan.EffectiveParameters, d = effectiveParameters(ctx, a.Meta.AWSClient.SSMClient, plan.Name.ValueString(), plan.DocumentVersion.ValueString(), plan.Parameters, exclude...)
+			diags.Append(d...)
+		}
 		plan.TagsAll = state.TagsAll
+		plan.TriggeredAlarms = triggeredAlarmsOut(ctx, output.AssociationDescription.TriggeredAlarms)
 	}
 
-	response.Diagnostics.Append(response.State.Set(ctx, &plan)...)
+	diags.Append(setSensitiveParameterKeys(ctx, private, sensitiveKeys)...)
+
+	return diags
+}
+
+// associationDefinitionChanged reports whether updating the association from state to plan
+// changes the association itself, which UpdateAssociation stores as a new association
+// version.  Attributes only used by the provider are not part of the definition, nor are
+// computed attributes that are unknown because other attributes changed.
+func associationDefinitionChanged(plan, state tftypes.Value) (bool, error) {
+	diffs, err := plan.Diff(state)
+	if err != nil {
+		return false, err
+	}
+
+	for _, valueDiff := range diffs {
+		// The root attribute holding the difference decides, differences of the whole
+		// object are also reported per attribute.
+		steps := valueDiff.Path.Steps()
+		if len(steps) == 0 {
+			continue
+		}
+
+		name, ok := steps[0].(tftypes.AttributeName)
+		if !ok || slices.Contains(associationProviderAttributes, string(name)) {
+			continue
+		}
+
+		if valueDiff.Value1 != nil && !valueDiff.Value1.IsKnown() {
+			continue
+		}
+
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// withAssociationProviderAttributes returns the state with the attributes only used by the
+// provider taken from the plan.
+func withAssociationProviderAttributes(state, plan AWSSSMAssociationResourceModel) AWSSSMAssociationResourceModel {
+	state.RunNowTriggers = plan.RunNowTriggers
+	state.WaitFor = plan.WaitFor
+	state.WaitForSuccessPercentage = plan.WaitForSuccessPercentage
+	state.WaitForSuccessTimeoutSeconds = plan.WaitForSuccessTimeoutSeconds
+
+	return state
+}
+
+// startAssociationOnce runs the association on all targets and, when
+// wait_for_success_timeout_seconds is set, waits for the run as for a new association.
+func startAssociationOnce(ctx context.Context, conn *ssm.Client, data AWSSSMAssociationResourceModel, diags *diag.Diagnostics) (*awstypes.AssociationDescription, error) {
+	associationId := data.AssociationId.ValueString()
+	started := time.Now()
+
+	previous, err := findLatestAssociationExecution(ctx, conn, associationId)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = conn.StartAssociationsOnce(ctx, &ssm.StartAssociationsOnceInput{
+		AssociationIds: []string{associationId},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return waitAssociationRun(ctx, conn, data, previous, started, diags)
+}
+
+// waitAssociationRun waits for the run of the association started after the previous
+// execution, when the plan waits for success.
+func waitAssociationRun(ctx context.Context, conn *ssm.Client, data AWSSSMAssociationResourceModel, previous *awstypes.AssociationExecution, started time.Time, diags *diag.Diagnostics) (*awstypes.AssociationDescription, error) {
+	if data.WaitForSuccessTimeoutSeconds.IsNull() || data.WaitForSuccessTimeoutSeconds.IsUnknown() {
+		return nil, nil
+	}
+
+	associationId := data.AssociationId.ValueString()
+	deadline := started.Add(time.Duration(data.WaitForSuccessTimeoutSeconds.ValueInt32()) * time.Second)
+
+	// The overview status is that of the previous run until the new execution starts.
+	if err := waitAssociationExecutionStarted(ctx, conn, associationId, previous, remainingWaitTimeout(deadline, time.Now())); err != nil {
+		return nil, err
+	}
+
+	return waitAssociationCreated(ctx, conn, associationId, remainingWaitTimeout(deadline, time.Now()), data.WaitFor.ValueString(), data.WaitForSuccessPercentage.ValueInt32(), diags)
+}
+
+// remainingWaitTimeout returns the time left until the deadline shared by the waits for a
+// run, at least associationMinimumWaitTimeout.
+func remainingWaitTimeout(deadline, now time.Time) time.Duration {
+	return max(deadline.Sub(now), associationMinimumWaitTimeout)
+}
+
+// waitAssociationExecutionStarted waits for an execution of the association newer than the
+// previous latest execution, which is nil when the association never ran.
+func waitAssociationExecutionStarted(ctx context.Context, conn *ssm.Client, id string, previous *awstypes.AssociationExecution, timeout time.Duration) error {
+	stateConf := &retry.StateChangeConf{
+		Pending: []string{associationExecutionStatusNotStarted},
+		Target:  []string{associationExecutionStatusStarted},
+		Refresh: func() (interface{}, string, error) {
+			latest, err := findLatestAssociationExecution(ctx, conn, id)
+			if err != nil {
+				return nil, "", err
+			}
+
+			if latest == nil || previous != nil && aws.ToString(latest.ExecutionId) == aws.ToString(previous.ExecutionId) {
+				return latest, associationExecutionStatusNotStarted, nil
+			}
+
+			return latest, associationExecutionStatusStarted, nil
+		},
+		Timeout: timeout,
+	}
+
+	_, err := stateConf.WaitForStateContext(ctx)
+
+	return err
 }
 
 func (a *AWSSSMAssociationResource) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse) {
@@ -565,7 +1105,124 @@ func (a *AWSSSMAssociationResource) Delete(ctx context.Context, request resource
 }
 
 func (a *AWSSSMAssociationResource) ImportState(ctx context.Context, request resource.ImportStateRequest, response *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("association_id"), request, response)
+	associationId, err := resolveAssociationImportID(ctx, a.Meta.AWSClient.SSMClient, request.ID)
+	if err != nil {
+		response.Diagnostics.AddError("Error importing SSM association", err.Error())
+		return
+	}
+
+	response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("association_id"), associationId)...)
+	response.Diagnostics.Append(setSensitiveParameterKeysUnknown(ctx, response.Private)...)
+}
+
+// resolveAssociationImportID accepts an association ID, name:<association_name> or
+// document:<document>/<target-key>=<value> and returns the matching association ID.
+func resolveAssociationImportID(ctx context.Context, conn *ssm.Client, importID string) (string, error) {
+	switch {
+	case strings.HasPrefix(importID, associationImportPrefixName):
+		associationName := strings.TrimPrefix(importID, associationImportPrefixName)
+		if associationName == "" {
+			return "", fmt.Errorf("unexpected format for import ID (%s), expected name:<association_name>", importID)
+		}
+
+		filter := awstypes.AssociationFilter{
+			Key:   awstypes.AssociationFilterKeyAssociationName,
+			Value: aws.String(associationName),
+		}
+
+		associations, err := findAssociations(ctx, conn, []awstypes.AssociationFilter{filter}, func(association awstypes.Association) bool {
+			return aws.ToString(association.AssociationName) == associationName
+		})
+		if err != nil {
+			return "", err
+		}
+
+		return singleAssociationID(importID, associations)
+	case strings.HasPrefix(importID, associationImportPrefixDocument):
+		documentName, targetKey, targetValue, err := parseAssociationDocumentImportID(strings.TrimPrefix(importID, associationImportPrefixDocument))
+		if err != nil {
+			return "", fmt.Errorf("unexpected format for import ID (%s), expected document:<document>/<target-key>=<value>: %s", importID, err)
+		}
+
+		filter := awstypes.AssociationFilter{
+			Key:   awstypes.AssociationFilterKeyName,
+			Value: aws.String(documentName),
+		}
+
+		associations, err := findAssociations(ctx, conn, []awstypes.AssociationFilter{filter}, func(association awstypes.Association) bool {
+			return aws.ToString(association.Name) == documentName && hasTargetValue(association.Targets, targetKey, targetValue)
+		})
+		if err != nil {
+			return "", err
+		}
+
+		return singleAssociationID(importID, associations)
+	}
+
+	return importID, nil
+}
+
+// parseAssociationDocumentImportID splits <document>/<target-key>=<value>.  Document names
+// cannot contain '/', so the separator is the first '/' after the document name, while
+// target keys such as tag:kubernetes.io/cluster may contain it.  Shared documents are
+// named by ARN, whose resource part ends in document/<name>.
+func parseAssociationDocumentImportID(id string) (string, string, string, error) {
+	start := 0
+	if strings.HasPrefix(id, "arn:") {
+		start = strings.Index(id, ":document/") + len(":document/")
+		if start < len(":document/") {
+			return "", "", "", errors.New("document ARN must contain :document/")
+		}
+	}
+
+	slash := strings.Index(id[start:], "/")
+	if slash < 0 {
+		return "", "", "", errors.New("missing '/' between document and target key")
+	}
+	slash += start
+
+	eq := strings.Index(id[slash:], "=")
+	if eq < 0 {
+		return "", "", "", errors.New("missing '=' between target key and value")
+	}
+	eq += slash
+
+	documentName, targetKey, targetValue := id[:slash], id[slash+1:eq], id[eq+1:]
+	if documentName == "" || targetKey == "" || targetValue == "" {
+		return "", "", "", errors.New("document, target key and value must not be empty")
+	}
+
+	return documentName, targetKey, targetValue, nil
+}
+
+func singleAssociationID(importID string, associations []awstypes.Association) (string, error) {
+	switch len(associations) {
+	case 0:
+		return "", fmt.Errorf("no SSM association matches import ID (%s)", importID)
+	case 1:
+		return aws.ToString(associations[0].AssociationId), nil
+	}
+
+	ids := make([]string, 0, len(associations))
+	for _, association := range associations {
+		ids = append(ids, aws.ToString(association.AssociationId))
+	}
+
+	return "", fmt.Errorf("import ID (%s) is ambiguous, it matches %d SSM associations: %s", importID, len(ids), strings.Join(ids, ", "))
+}
+
+func hasTargetValue(targets []awstypes.Target, key, value string) bool {
+	for _, target := range targets {
+		if aws.ToString(target.Key) != key {
+			continue
+		}
+
+		if slices.Contains(target.Values, value) {
+			return true
+		}
+	}
+
+	return false
 }
 
 func FindAssociationByID(ctx context.Context, conn *ssm.Client, id string) (*awstypes.AssociationDescription, error) {
@@ -586,6 +1243,30 @@ func FindAssociationByID(ctx context.Context, conn *ssm.Client, id string) (*aws
 	return output.AssociationDescription, nil
 }
 
+func findAssociations(ctx context.Context, conn *ssm.Client, filters []awstypes.AssociationFilter, match func(awstypes.Association) bool) ([]awstypes.Association, error) {
+	input := &ssm.ListAssociationsInput{
+		AssociationFilterList: filters,
+	}
+
+	var associations []awstypes.Association
+
+	pages := ssm.NewListAssociationsPaginator(conn, input)
+	for pages.HasMorePages() {
+		page, err := pages.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, association := range page.Associations {
+			if match == nil || match(association) {
+				associations = append(associations, association)
+			}
+		}
+	}
+
+	return associations, nil
+}
+
 func findAssociationTagsByID(ctx context.Context, conn *ssm.Client, id string) ([]awstypes.Tag, error) {
 	input := &ssm.ListTagsForResourceInput{
 		ResourceId:   aws.String(id),
@@ -601,19 +1282,40 @@ func findAssociationTagsByID(ctx context.Context, conn *ssm.Client, id string) (
 	return output.TagList, nil
 }
 
-func waitAssociationCreated(ctx context.Context, conn *ssm.Client, id string, timeout time.Duration, diag diag.Diagnostics) (*awstypes.AssociationDescription, error) {
+// waitAssociationCreated waits for the latest run of the association, until the targets
+// completed as required by the wait_for mode.
+func waitAssociationCreated(ctx context.Context, conn *ssm.Client, id string, timeout time.Duration, waitFor string, percentage int32, diags *diag.Diagnostics) (*awstypes.AssociationDescription, error) {
 	stateConf := &retry.StateChangeConf{
 		Pending: []string{string(awstypes.AssociationStatusNamePending)},
-		Target:  []string{string(awstypes.AssociationStatusNameSuccess)},
-		Refresh: statusAssociation(ctx, conn, id),
+		Target: []string{
+			string(awstypes.AssociationStatusNameSuccess),
+			associationStatusCalendarClosed,
+		},
+		Refresh: statusAssociation(ctx, conn, id, waitFor, percentage),
 		Timeout: timeout,
 	}
 
 	outputRaw, err := stateConf.WaitForStateContext(ctx)
 
 	if output, ok := outputRaw.(*awstypes.AssociationDescription); ok {
-		if status := awstypes.AssociationStatusName(aws.ToString(output.Overview.Status)); status == awstypes.AssociationStatusNameFailed {
-			diag.AddError("Association error", aws.ToString(output.Overview.DetailedStatus))
+		if err == nil && awstypes.AssociationStatusName(aws.ToString(output.Overview.Status)) == awstypes.AssociationStatusNamePending {
+			// The association is still pending, so the wait ended because a change calendar is closed.
+			detail := fmt.Sprintf("SSM Association (%s) was not run because change calendar %s is %s.", id, strings.Join(output.CalendarNames, ", "), awstypes.CalendarStateClosed)
+			if calendar, err := findCalendarState(ctx, conn, output.CalendarNames); err == nil && calendar.NextTransitionTime != nil {
+				detail += fmt.Sprintf("  The calendar next opens at %s.", aws.ToString(calendar.NextTransitionTime))
+			}
+			diags.AddWarning("Association run skipped", detail)
+		}
+
+		status := associationWaitStatus(output, waitFor, percentage)
+
+		if status == string(awstypes.AssociationStatusNameFailed) && output.Overview != nil && aws.ToString(output.Overview.Status) == status {
+			diags.AddError("Association error", aws.ToString(output.Overview.DetailedStatus))
+		}
+
+		if err != nil {
+			appendAssociationTargetsDiagnostic(ctx, conn, diags, id, output)
+			appendTriggeredAlarmsDiagnostic(diags, output.TriggeredAlarms)
 		}
 
 		return output, err
@@ -622,7 +1324,7 @@ func waitAssociationCreated(ctx context.Context, conn *ssm.Client, id string, ti
 	return nil, err
 }
 
-func statusAssociation(ctx context.Context, conn *ssm.Client, id string) retry.StateRefreshFunc {
+func statusAssociation(ctx context.Context, conn *ssm.Client, id string, waitFor string, percentage int32) retry.StateRefreshFunc {
 	return func() (interface{}, string, error) {
 		output, err := FindAssociationByID(ctx, conn, id)
 
@@ -630,10 +1332,161 @@ func statusAssociation(ctx context.Context, conn *ssm.Client, id string) retry.S
 			return nil, "", err
 		}
 
+		status := associationWaitStatus(output, waitFor, percentage)
+
+		// A closed change calendar keeps the association pending until it opens again.
+		if status == string(awstypes.AssociationStatusNamePending) && len(output.CalendarNames) > 0 {
+			calendar, err := findCalendarState(ctx, conn, output.CalendarNames)
+			if err != nil {
+				return nil, "", err
+			}
+
+			if calendar.State == awstypes.CalendarStateClosed {
+				return output, associationStatusCalendarClosed, nil
+			}
+		}
+
+		return output, status, nil
+	}
+}
+
+// associationWaitStatus returns the status of the association for the wait_for mode,
+// Pending, Success or Failed.
+func associationWaitStatus(association *awstypes.AssociationDescription, waitFor string, percentage int32) string {
+	if association.Overview == nil {
+		return string(awstypes.AssociationStatusNamePending)
+	}
+
+	switch waitFor {
+	case associationWaitForAllTargets:
+		return associationTargetsStatus(association.Overview.AssociationStatusAggregatedCount, 100)
+	case associationWaitForPercentage:
+		return associationTargetsStatus(association.Overview.AssociationStatusAggregatedCount, percentage)
+	default:
 		// Use the Overview.Status field instead of the root-level Status as DescribeAssociation
 		// does not appear to return the root-level Status in the API response at this time.
-		return output, aws.ToString(output.Overview.Status), nil
+		return aws.ToString(association.Overview.Status)
+	}
+}
+
+// associationTargetsStatus returns Success once the percentage of the targets succeeded,
+// Failed once the percentage can no longer be reached and Pending otherwise.  Skipped
+// targets count as succeeded.  The status is Pending until the first target reports.
+func associationTargetsStatus(counts map[string]int32, percentage int32) string {
+	var total, succeeded, pending int32
+
+	for status, count := range counts {
+		total += count
+
+		switch {
+		case isAssociationTargetPending(status):
+			pending += count
+		case isAssociationTargetSucceeded(status):
+			succeeded += count
+		}
 	}
+
+	switch {
+	case total == 0:
+		return string(awstypes.AssociationStatusNamePending)
+	case succeeded*100 >= percentage*total && (percentage < 100 || pending == 0):
+		return string(awstypes.AssociationStatusNameSuccess)
+	case (succeeded+pending)*100 < percentage*total:
+		return string(awstypes.AssociationStatusNameFailed)
+	default:
+		return string(awstypes.AssociationStatusNamePending)
+	}
+}
+
+func isAssociationTargetPending(status string) bool {
+	return status == string(awstypes.AssociationStatusNamePending) || status == "InProgress"
+}
+
+func isAssociationTargetSucceeded(status string) bool {
+	return status == string(awstypes.AssociationStatusNameSuccess) || status == "Skipped"
+}
+
+// appendAssociationTargetsDiagnostic reports the targets of the latest execution of the
+// association that have not completed yet or did not succeed.
+func appendAssociationTargetsDiagnostic(ctx context.Context, conn *ssm.Client, diags *diag.Diagnostics, id string, association *awstypes.AssociationDescription) {
+	var counts []string
+	if association.Overview != nil {
+		for status, count := range association.Overview.AssociationStatusAggregatedCount {
+			counts = append(counts, fmt.Sprintf("%s=%d", status, count))
+		}
+	}
+	slices.Sort(counts)
+
+	detail := fmt.Sprintf("SSM Association (%s) target status: {%s}.", id, strings.Join(counts, ", "))
+
+	stragglers, failed, err := findAssociationIncompleteTargets(ctx, conn, id)
+	if err != nil {
+		detail += fmt.Sprintf("  Unable to read the targets of the latest execution: %s", err)
+	} else {
+		if len(stragglers) > 0 {
+			detail += fmt.Sprintf("\n\nTargets still running: %s", strings.Join(stragglers, ", "))
+		}
+		if len(failed) > 0 {
+			detail += fmt.Sprintf("\n\nTargets that did not succeed: %s", strings.Join(failed, ", "))
+		}
+	}
+
+	diags.AddError("Association targets incomplete", detail)
+}
+
+// findLatestAssociationExecution returns the most recently created execution of the
+// association, or nil when the association never ran.
+func findLatestAssociationExecution(ctx context.Context, conn *ssm.Client, id string) (*awstypes.AssociationExecution, error) {
+	// Executions are returned newest first, only the first one is needed.
+	output, err := conn.DescribeAssociationExecutions(ctx, &ssm.DescribeAssociationExecutionsInput{
+		AssociationId: aws.String(id),
+		MaxResults:    aws.Int32(1),
+	})
+	if err != nil || len(output.AssociationExecutions) == 0 {
+		return nil, err
+	}
+
+	return &output.AssociationExecutions[0], nil
+}
+
+// findAssociationIncompleteTargets returns the resource IDs of the targets of the latest
+// execution of the association that are still running and those that did not succeed.
+func findAssociationIncompleteTargets(ctx context.Context, conn *ssm.Client, id string) ([]string, []string, error) {
+	latest, err := findLatestAssociationExecution(ctx, conn, id)
+	if err != nil || latest == nil {
+		return nil, nil, err
+	}
+
+	targets, err := findAssociationExecutionTargets(ctx, conn, id, aws.ToString(latest.ExecutionId), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var stragglers, failed []string
+
+	for _, target := range targets {
+		status := aws.ToString(target.Status)
+
+		switch {
+		case isAssociationTargetPending(status):
+			stragglers = append(stragglers, aws.ToString(target.ResourceId))
+		case !isAssociationTargetSucceeded(status):
+			failed = append(failed, fmt.Sprintf("%s (%s)", aws.ToString(target.ResourceId), status))
+		}
+	}
+
+	slices.Sort(stragglers)
+	slices.Sort(failed)
+
+	return stragglers, failed, nil
+}
+
+func findCalendarState(ctx context.Context, conn *ssm.Client, calendarNames []string) (*ssm.GetCalendarStateOutput, error) {
+	input := &ssm.GetCalendarStateInput{
+		CalendarNames: calendarNames,
+	}
+
+	return conn.GetCalendarState(ctx, input)
 }
 
 func isAutoSSMTarget(targets []awstypes.Target) bool {
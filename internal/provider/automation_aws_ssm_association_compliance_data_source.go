@@ -0,0 +1,362 @@
+package provider
+
+import (
+	"context"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	awstypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"slices"
+)
+
+// Compliance types and resource types reported by Systems Manager Compliance.
+const (
+	complianceTypeAssociation             = "Association"
+	complianceResourceTypeManagedInstance = "ManagedInstance"
+	complianceFilterKeyComplianceType     = "ComplianceType"
+)
+
+var _ datasource.DataSource = &AWSSSMAssociationComplianceDataSource{}
+var _ datasource.DataSourceWithConfigure = &AWSSSMAssociationComplianceDataSource{}
+
+type AWSSSMAssociationComplianceDataSource struct {
+	Meta Meta
+}
+
+type AWSSSMAssociationComplianceDataSourceModel struct {
+	AssociationId           types.String `tfsdk:"association_id"`
+	CompliantResourceIds    types.List   `tfsdk:"compliant_resource_ids"`
+	Items                   types.List   `tfsdk:"items"`
+	NonCompliantResourceIds types.List   `tfsdk:"non_compliant_resource_ids"`
+	Targets                 TargetsValue `tfsdk:"targets"`
+}
+
+type ComplianceItemModel struct {
+	AssociationId types.String `tfsdk:"association_id"`
+	ExecutionId   types.String `tfsdk:"execution_id"`
+	ExecutionTime types.String `tfsdk:"execution_time"`
+	ResourceId    types.String `tfsdk:"resource_id"`
+	ResourceType  types.String `tfsdk:"resource_type"`
+	Severity      types.String `tfsdk:"severity"`
+	Status        types.String `tfsdk:"status"`
+	Title         types.String `tfsdk:"title"`
+}
+
+var complianceItemObjectType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"association_id": types.StringType,
+		"execution_id":   types.StringType,
+		"execution_time": types.StringType,
+		"resource_id":    types.StringType,
+		"resource_type":  types.StringType,
+		"severity":       types.StringType,
+		"status":         types.StringType,
+		"title":          types.StringType,
+	},
+}
+
+func newAWSSSMAssociationComplianceDataSource() datasource.DataSource {
+	return &AWSSSMAssociationComplianceDataSource{}
+}
+
+func (a *AWSSSMAssociationComplianceDataSource) Configure(_ context.Context, request datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if request.ProviderData == nil {
+		return
+	}
+
+	a.Meta = request.ProviderData.(Meta)
+}
+
+func (a *AWSSSMAssociationComplianceDataSource) Metadata(_ context.Context, request datasource.MetadataRequest, response *datasource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_aws_ssm_association_compliance"
+}
+
+func (a *AWSSSMAssociationComplianceDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, response *datasource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		MarkdownDescription: "Reads the `Association` compliance of managed instances, for an association, a set of targets or both.",
+		Attributes: map[string]schema.Attribute{
+			"association_id": schema.StringAttribute{
+				Description: "Only the compliance of this association.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.AtLeastOneOf(path.MatchRoot("targets")),
+				},
+			},
+			"compliant_resource_ids": schema.ListAttribute{
+				Description: "The resources whose items are all compliant.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"items": schema.ListNestedAttribute{
+				Description: "The compliance items, one per association and resource.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"association_id": schema.StringAttribute{
+							Description: "The ID of the association.",
+							Computed:    true,
+						},
+						"execution_id": schema.StringAttribute{
+							Description: "The ID of the execution that reported the compliance.",
+							Computed:    true,
+						},
+						"execution_time": schema.StringAttribute{
+							Description: "The time the compliance was reported.",
+							Computed:    true,
+						},
+						"resource_id": schema.StringAttribute{
+							Description: "The ID of the resource, e.g. an instance ID.",
+							Computed:    true,
+						},
+						"resource_type": schema.StringAttribute{
+							Description: "The type of the resource.",
+							Computed:    true,
+						},
+						"severity": schema.StringAttribute{
+							Description: "The compliance severity of the association.",
+							Computed:    true,
+						},
+						"status": schema.StringAttribute{
+							Description: "The compliance status, COMPLIANT or NON_COMPLIANT.",
+							Computed:    true,
+						},
+						"title": schema.StringAttribute{
+							Description: "The title of the compliance item.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"non_compliant_resource_ids": schema.ListAttribute{
+				Description: "The resources with at least one non-compliant item.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"targets": schema.ListNestedAttribute{
+				MarkdownDescription: "Only the compliance of the managed instances these targets resolve to, see the `automation_aws_ssm_managed_instances` data source.",
+				Optional:            true,
+				CustomType:          NewTargetsType(),
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"key": schema.StringAttribute{
+							Description: "The target key.",
+							Required:    true,
+						},
+						"values": schema.ListAttribute{
+							Description: "The values for the target key.",
+							Required:    true,
+							ElementType: types.StringType,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (a *AWSSSMAssociationComplianceDataSource) Read(ctx context.Context, request datasource.ReadRequest, response *datasource.ReadResponse) {
+	var data AWSSSMAssociationComplianceDataSourceModel
+
+	response.Diagnostics.Append(request.Config.Get(ctx, &data)...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	conn := a.Meta.AWSClient.SSMClient
+
+	var resourceIds []string
+
+	if data.Targets.IsNull() {
+		summaries, err := findResourceComplianceSummaries(ctx, conn, complianceTypeAssociation)
+		if err != nil {
+			response.Diagnostics.AddError("Error reading compliance summaries", err.Error())
+			return
+		}
+
+		for _, summary := range summaries {
+			if aws.ToString(summary.ResourceType) == complianceResourceTypeManagedInstance {
+				resourceIds = append(resourceIds, aws.ToString(summary.ResourceId))
+			}
+		}
+	} else {
+		targets, diags := targetsIn(ctx, data.Targets.ListValue)
+		response.Diagnostics.Append(diags...)
+
+		if response.Diagnostics.HasError() {
+			return
+		}
+
+		query, err := newInstanceTargetQuery(targets)
+		if err != nil {
+			response.Diagnostics.AddAttributeError(path.Root("targets"), "Invalid targets", err.Error())
+			return
+		}
+
+		if query.resourceGroup != "" {
+			ids, err := findResourceGroupInstanceIDs(ctx, a.Meta.AWSClient.ResourceGroupsClient, query.resourceGroup, query.resourceTypes)
+			if err != nil {
+				response.Diagnostics.AddError("Error reading resource group", err.Error())
+				return
+			}
+
+			query.restrict(ids)
+		}
+
+		instances, err := findManagedInstances(ctx, conn, query)
+		if err != nil {
+			response.Diagnostics.AddError("Error reading managed instances", err.Error())
+			return
+		}
+
+		for _, instance := range instances {
+			resourceIds = append(resourceIds, aws.ToString(instance.InstanceId))
+		}
+	}
+
+	slices.Sort(resourceIds)
+
+	var items []awstypes.ComplianceItem
+
+	for _, resourceId := range slices.Compact(resourceIds) {
+		resourceItems, err := findComplianceItems(ctx, conn, resourceId, complianceResourceTypeManagedInstance, complianceTypeAssociation)
+		if err != nil {
+			response.Diagnostics.AddError("Error reading compliance items", err.Error())
+			return
+		}
+
+		for _, item := range resourceItems {
+			// The items of the Association compliance type are identified by association ID.
+			if data.AssociationId.IsNull() || aws.ToString(item.Id) == data.AssociationId.ValueString() {
+				items = append(items, item)
+			}
+		}
+	}
+
+	compliant, nonCompliant := complianceResourceIDs(items)
+
+	var diags diag.Diagnostics
+	data.CompliantResourceIds, diags = types.ListValueFrom(ctx, types.StringType, compliant)
+	response.Diagnostics.Append(diags...)
+
+	data.NonCompliantResourceIds, diags = types.ListValueFrom(ctx, types.StringType, nonCompliant)
+	response.Diagnostics.Append(diags...)
+
+	data.Items, diags = types.ListValueFrom(ctx, complianceItemObjectType, complianceItemsOut(items))
+	response.Diagnostics.Append(diags...)
+
+	response.Diagnostics.Append(response.State.Set(ctx, &data)...)
+}
+
+func findResourceComplianceSummaries(ctx context.Context, conn *ssm.Client, complianceType string) ([]awstypes.ResourceComplianceSummaryItem, error) {
+	input := &ssm.ListResourceComplianceSummariesInput{
+		Filters: []awstypes.ComplianceStringFilter{
+			{
+				Key:    aws.String(complianceFilterKeyComplianceType),
+				Type:   awstypes.ComplianceQueryOperatorTypeEqual,
+				Values: []string{complianceType},
+			},
+		},
+	}
+
+	var summaries []awstypes.ResourceComplianceSummaryItem
+
+	pages := ssm.NewListResourceComplianceSummariesPaginator(conn, input)
+	for pages.HasMorePages() {
+		page, err := pages.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		summaries = append(summaries, page.ResourceComplianceSummaryItems...)
+	}
+
+	return summaries, nil
+}
+
+func findComplianceItems(ctx context.Context, conn *ssm.Client, resourceId, resourceType, complianceType string) ([]awstypes.ComplianceItem, error) {
+	input := &ssm.ListComplianceItemsInput{
+		Filters: []awstypes.ComplianceStringFilter{
+			{
+				Key:    aws.String(complianceFilterKeyComplianceType),
+				Type:   awstypes.ComplianceQueryOperatorTypeEqual,
+				Values: []string{complianceType},
+			},
+		},
+		ResourceIds:   []string{resourceId},
+		ResourceTypes: []string{resourceType},
+	}
+
+	var items []awstypes.ComplianceItem
+
+	pages := ssm.NewListComplianceItemsPaginator(conn, input)
+	for pages.HasMorePages() {
+		page, err := pages.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, page.ComplianceItems...)
+	}
+
+	return items, nil
+}
+
+// complianceResourceIDs splits the resources of the items into compliant resources and
+// resources with at least one non-compliant item.
+func complianceResourceIDs(items []awstypes.ComplianceItem) ([]string, []string) {
+	compliant := []string{}
+	nonCompliant := []string{}
+
+	for _, item := range items {
+		if item.Status == awstypes.ComplianceStatusNonCompliant && !slices.Contains(nonCompliant, aws.ToString(item.ResourceId)) {
+			nonCompliant = append(nonCompliant, aws.ToString(item.ResourceId))
+		}
+	}
+
+	for _, item := range items {
+		id := aws.ToString(item.ResourceId)
+
+		if !slices.Contains(nonCompliant, id) && !slices.Contains(compliant, id) {
+			compliant = append(compliant, id)
+		}
+	}
+
+	slices.Sort(compliant)
+	slices.Sort(nonCompliant)
+
+	return compliant, nonCompliant
+}
+
+func complianceItemsOut(items []awstypes.ComplianceItem) []ComplianceItemModel {
+	result := make([]ComplianceItemModel, 0, len(items))
+
+	for _, item := range items {
+		model := ComplianceItemModel{
+			AssociationId: types.StringPointerValue(item.Id),
+			ExecutionId:   types.StringNull(),
+			ExecutionTime: types.StringNull(),
+			ResourceId:    types.StringPointerValue(item.ResourceId),
+			ResourceType:  types.StringPointerValue(item.ResourceType),
+			Severity:      types.StringValue(string(item.Severity)),
+			Status:        types.StringValue(string(item.Status)),
+			Title:         types.StringPointerValue(item.Title),
+		}
+
+		if item.ExecutionSummary != nil {
+			model.ExecutionId = types.StringPointerValue(item.ExecutionSummary.ExecutionId)
+			model.ExecutionTime = timeOut(item.ExecutionSummary.ExecutionTime)
+		}
+
+		result = append(result, model)
+	}
+
+	return result
+}
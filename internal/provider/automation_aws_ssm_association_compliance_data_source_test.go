@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awstypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"regexp"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestAccSSMAssociationComplianceDataSource_basic(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	dataSourceName := "data.automation_aws_ssm_association_compliance.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAssociationComplianceDataSourceConfig_basic(rName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "items.#", "0"),
+					resource.TestCheckResourceAttr(dataSourceName, "compliant_resource_ids.#", "0"),
+					resource.TestCheckResourceAttr(dataSourceName, "non_compliant_resource_ids.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSSMAssociationComplianceDataSource_missingFilter(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "automation_aws_ssm_association_compliance" "test" {}
+`,
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+		},
+	})
+}
+
+func TestComplianceResourceIDs(t *testing.T) {
+	items := []awstypes.ComplianceItem{
+		{ResourceId: aws.String("i-2"), Status: awstypes.ComplianceStatusCompliant},
+		{ResourceId: aws.String("i-1"), Status: awstypes.ComplianceStatusCompliant},
+		{ResourceId: aws.String("i-3"), Status: awstypes.ComplianceStatusCompliant},
+		{ResourceId: aws.String("i-3"), Status: awstypes.ComplianceStatusNonCompliant},
+		{ResourceId: aws.String("i-1"), Status: awstypes.ComplianceStatusCompliant},
+	}
+
+	compliant, nonCompliant := complianceResourceIDs(items)
+
+	if want := []string{"i-1", "i-2"}; !slices.Equal(compliant, want) {
+		t.Errorf("expected compliant %v, got %v", want, compliant)
+	}
+
+	if want := []string{"i-3"}; !slices.Equal(nonCompliant, want) {
+		t.Errorf("expected non-compliant %v, got %v", want, nonCompliant)
+	}
+}
+
+func TestComplianceItemsOut(t *testing.T) {
+	items := []awstypes.ComplianceItem{
+		{
+			ExecutionSummary: &awstypes.ComplianceExecutionSummary{
+				ExecutionId:   aws.String("exec-1"),
+				ExecutionTime: aws.Time(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+			},
+			Id:           aws.String("assoc-1"),
+			ResourceId:   aws.String("i-1"),
+			ResourceType: aws.String(complianceResourceTypeManagedInstance),
+			Severity:     awstypes.ComplianceSeverityHigh,
+			Status:       awstypes.ComplianceStatusNonCompliant,
+		},
+		{
+			Id:         aws.String("assoc-2"),
+			ResourceId: aws.String("i-2"),
+			Severity:   awstypes.ComplianceSeverityUnspecified,
+			Status:     awstypes.ComplianceStatusCompliant,
+		},
+	}
+
+	got := complianceItemsOut(items)
+
+	if len(got) != 2 {
+		t.Fatalf("expected 2 items, got %d", len(got))
+	}
+
+	if got[0].AssociationId.ValueString() != "assoc-1" || got[0].ExecutionId.ValueString() != "exec-1" || got[0].Severity.ValueString() != "HIGH" || got[0].Status.ValueString() != "NON_COMPLIANT" {
+		t.Errorf("unexpected item %+v", got[0])
+	}
+
+	if got[0].ExecutionTime.ValueString() != "2024-01-01T00:00:00Z" {
+		t.Errorf("expected execution time 2024-01-01T00:00:00Z, got %s", got[0].ExecutionTime.ValueString())
+	}
+
+	if !got[1].ExecutionId.IsNull() || !got[1].ExecutionTime.IsNull() {
+		t.Errorf("expected null execution, got %+v", got[1])
+	}
+}
+
+func testAccAssociationComplianceDataSourceConfig_basic(rName string) string {
+	return fmt.Sprintf(`
+data "automation_aws_ssm_association_compliance" "test" {
+  targets = [{
+    key    = "tag:Name"
+    values = [%[1]q]
+  }]
+}
+`, rName)
+}
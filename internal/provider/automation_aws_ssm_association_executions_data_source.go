@@ -0,0 +1,461 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	awstypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"strconv"
+	"strings"
+)
+
+// defaultMaxAssociationExecutions is the number of executions listed when max_executions
+// is not set.  Each execution costs a request for its targets.
+const defaultMaxAssociationExecutions = 10
+
+// associationExecutionsPageSize is the largest page DescribeAssociationExecutions returns.
+const associationExecutionsPageSize = 50
+
+var _ datasource.DataSource = &AWSSSMAssociationExecutionsDataSource{}
+var _ datasource.DataSourceWithConfigure = &AWSSSMAssociationExecutionsDataSource{}
+
+type AWSSSMAssociationExecutionsDataSource struct {
+	Meta Meta
+}
+
+type AWSSSMAssociationExecutionsDataSourceModel struct {
+	AssociationId    types.String                      `tfsdk:"association_id"`
+	ExecutionFilters []AssociationExecutionFilterModel `tfsdk:"execution_filters"`
+	Executions       types.List                        `tfsdk:"executions"`
+	MaxExecutions    types.Int32                       `tfsdk:"max_executions"`
+	TargetFilters    []AssociationFilterModel          `tfsdk:"target_filters"`
+}
+
+type AssociationExecutionFilterModel struct {
+	Key   types.String `tfsdk:"key"`
+	Type  types.String `tfsdk:"type"`
+	Value types.String `tfsdk:"value"`
+}
+
+type AssociationExecutionModel struct {
+	AssociationVersion    types.String `tfsdk:"association_version"`
+	CreatedTime           types.String `tfsdk:"created_time"`
+	DetailedStatus        types.String `tfsdk:"detailed_status"`
+	ExecutionId           types.String `tfsdk:"execution_id"`
+	LastExecutionDate     types.String `tfsdk:"last_execution_date"`
+	ResourceCountByStatus types.Map    `tfsdk:"resource_count_by_status"`
+	Status                types.String `tfsdk:"status"`
+	Targets               types.List   `tfsdk:"targets"`
+}
+
+type AssociationExecutionTargetModel struct {
+	DetailedStatus    types.String `tfsdk:"detailed_status"`
+	LastExecutionDate types.String `tfsdk:"last_execution_date"`
+	OutputSourceId    types.String `tfsdk:"output_source_id"`
+	OutputSourceType  types.String `tfsdk:"output_source_type"`
+	ResourceId        types.String `tfsdk:"resource_id"`
+	ResourceType      types.String `tfsdk:"resource_type"`
+	Status            types.String `tfsdk:"status"`
+}
+
+var associationExecutionTargetObjectType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"detailed_status":     types.StringType,
+		"last_execution_date": types.StringType,
+		"output_source_id":    types.StringType,
+		"output_source_type":  types.StringType,
+		"resource_id":         types.StringType,
+		"resource_type":       types.StringType,
+		"status":              types.StringType,
+	},
+}
+
+var associationExecutionObjectType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"association_version":      types.StringType,
+		"created_time":             types.StringType,
+		"detailed_status":          types.StringType,
+		"execution_id":             types.StringType,
+		"last_execution_date":      types.StringType,
+		"resource_count_by_status": types.MapType{ElemType: types.Int64Type},
+		"status":                   types.StringType,
+		"targets":                  types.ListType{ElemType: associationExecutionTargetObjectType},
+	},
+}
+
+func newAWSSSMAssociationExecutionsDataSource() datasource.DataSource {
+	return &AWSSSMAssociationExecutionsDataSource{}
+}
+
+func (a *AWSSSMAssociationExecutionsDataSource) Configure(_ context.Context, request datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if request.ProviderData == nil {
+		return
+	}
+
+	a.Meta = request.ProviderData.(Meta)
+}
+
+func (a *AWSSSMAssociationExecutionsDataSource) Metadata(_ context.Context, request datasource.MetadataRequest, response *datasource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_aws_ssm_association_executions"
+}
+
+func (a *AWSSSMAssociationExecutionsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, response *datasource.SchemaResponse) {
+	executionFilterKeys := make([]string, 0, len(awstypes.AssociationExecutionFilterKey("").Values()))
+	for _, key := range awstypes.AssociationExecutionFilterKey("").Values() {
+		executionFilterKeys = append(executionFilterKeys, string(key))
+	}
+
+	filterTypes := make([]string, 0, len(awstypes.AssociationFilterOperatorType("").Values()))
+	for _, filterType := range awstypes.AssociationFilterOperatorType("").Values() {
+		filterTypes = append(filterTypes, string(filterType))
+	}
+
+	targetFilterKeys := make([]string, 0, len(awstypes.AssociationExecutionTargetsFilterKey("").Values()))
+	for _, key := range awstypes.AssociationExecutionTargetsFilterKey("").Values() {
+		targetFilterKeys = append(targetFilterKeys, string(key))
+	}
+
+	response.Schema = schema.Schema{
+		MarkdownDescription: "Lists the executions of an SSM association, most recent first, with the result on each target.",
+		Attributes: map[string]schema.Attribute{
+			"association_id": schema.StringAttribute{
+				Description: "The ID of the association.",
+				Required:    true,
+			},
+			"execution_filters": schema.ListNestedAttribute{
+				Description: "Only executions matching all of these filters.",
+				Optional:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"key": schema.StringAttribute{
+							Description: "The name of the filter, ExecutionId, Status or CreatedTime.",
+							Required:    true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(executionFilterKeys...),
+							},
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "How the value is compared, defaults to `EQUAL`.",
+							Optional:            true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(filterTypes...),
+							},
+						},
+						"value": schema.StringAttribute{
+							Description: "The value of the filter.",
+							Required:    true,
+						},
+					},
+				},
+			},
+			"executions": schema.ListNestedAttribute{
+				Description: "The matching executions.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"association_version": schema.StringAttribute{
+							Description: "The version of the association that ran.",
+							Computed:    true,
+						},
+						"created_time": schema.StringAttribute{
+							Description: "The time the execution started.",
+							Computed:    true,
+						},
+						"detailed_status": schema.StringAttribute{
+							Description: "The detailed status of the execution.",
+							Computed:    true,
+						},
+						"execution_id": schema.StringAttribute{
+							Description: "The ID of the execution.",
+							Computed:    true,
+						},
+						"last_execution_date": schema.StringAttribute{
+							Description: "The time the execution last ran.",
+							Computed:    true,
+						},
+						"resource_count_by_status": schema.MapAttribute{
+							Description: "The number of targets by status.",
+							Computed:    true,
+							ElementType: types.Int64Type,
+						},
+						"status": schema.StringAttribute{
+							Description: "The status of the execution.",
+							Computed:    true,
+						},
+						"targets": schema.ListNestedAttribute{
+							Description: "The result of the execution on each target matching target_filters.",
+							Computed:    true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"detailed_status": schema.StringAttribute{
+										Description: "The detailed status on the target.",
+										Computed:    true,
+									},
+									"last_execution_date": schema.StringAttribute{
+										Description: "The time the execution last ran on the target.",
+										Computed:    true,
+									},
+									"output_source_id": schema.StringAttribute{
+										Description: "The ID of the output source, e.g. the Run Command ID.",
+										Computed:    true,
+									},
+									"output_source_type": schema.StringAttribute{
+										Description: "The type of the output source, e.g. RunCommand.",
+										Computed:    true,
+									},
+									"resource_id": schema.StringAttribute{
+										Description: "The ID of the target, e.g. an instance ID.",
+										Computed:    true,
+									},
+									"resource_type": schema.StringAttribute{
+										Description: "The type of the target, e.g. ManagedInstance.",
+										Computed:    true,
+									},
+									"status": schema.StringAttribute{
+										Description: "The status on the target.",
+										Computed:    true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"max_executions": schema.Int32Attribute{
+				MarkdownDescription: fmt.Sprintf("The maximum number of executions to list, defaults to %d.  The targets of each execution are read with a separate request.", defaultMaxAssociationExecutions),
+				Optional:            true,
+				Validators: []validator.Int32{
+					int32validator.AtLeast(1),
+				},
+			},
+			"target_filters": schema.ListNestedAttribute{
+				Description: "Only targets matching all of these filters.",
+				Optional:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"key": schema.StringAttribute{
+							Description: "The name of the filter, Status, ResourceId or ResourceType.",
+							Required:    true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(targetFilterKeys...),
+							},
+						},
+						"value": schema.StringAttribute{
+							Description: "The value of the filter.",
+							Required:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (a *AWSSSMAssociationExecutionsDataSource) Read(ctx context.Context, request datasource.ReadRequest, response *datasource.ReadResponse) {
+	var data AWSSSMAssociationExecutionsDataSourceModel
+
+	response.Diagnostics.Append(request.Config.Get(ctx, &data)...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	conn := a.Meta.AWSClient.SSMClient
+	associationId := data.AssociationId.ValueString()
+
+	executionFilters := make([]awstypes.AssociationExecutionFilter, 0, len(data.ExecutionFilters))
+	for _, filter := range data.ExecutionFilters {
+		filterType := awstypes.AssociationFilterOperatorTypeEqual
+		if !filter.Type.IsNull() {
+			filterType = awstypes.AssociationFilterOperatorType(filter.Type.ValueString())
+		}
+
+		executionFilters = append(executionFilters, awstypes.AssociationExecutionFilter{
+			Key:   awstypes.AssociationExecutionFilterKey(filter.Key.ValueString()),
+			Type:  filterType,
+			Value: filter.Value.ValueStringPointer(),
+		})
+	}
+
+	targetFilters := make([]awstypes.AssociationExecutionTargetsFilter, 0, len(data.TargetFilters))
+	for _, filter := range data.TargetFilters {
+		targetFilters = append(targetFilters, awstypes.AssociationExecutionTargetsFilter{
+			Key:   awstypes.AssociationExecutionTargetsFilterKey(filter.Key.ValueString()),
+			Value: filter.Value.ValueStringPointer(),
+		})
+	}
+
+	maxExecutions := defaultMaxAssociationExecutions
+	if !data.MaxExecutions.IsNull() {
+		maxExecutions = int(data.MaxExecutions.ValueInt32())
+	}
+
+	executions, err := findAssociationExecutions(ctx, conn, associationId, executionFilters, maxExecutions)
+	if err != nil {
+		response.Diagnostics.AddError("Error reading association executions", err.Error())
+		return
+	}
+
+	result := make([]AssociationExecutionModel, 0, len(executions))
+
+	for _, execution := range executions {
+		targets, err := findAssociationExecutionTargets(ctx, conn, associationId, aws.ToString(execution.ExecutionId), targetFilters)
+		if err != nil {
+			response.Diagnostics.AddError("Error reading association execution targets", err.Error())
+			return
+		}
+
+		model, diags := associationExecutionOut(ctx, execution, targets)
+		response.Diagnostics.Append(diags...)
+
+		if response.Diagnostics.HasError() {
+			return
+		}
+
+		result = append(result, model)
+	}
+
+	var diags diag.Diagnostics
+	data.Executions, diags = types.ListValueFrom(ctx, associationExecutionObjectType, result)
+	response.Diagnostics.Append(diags...)
+
+	response.Diagnostics.Append(response.State.Set(ctx, &data)...)
+}
+
+// findAssociationExecutions returns at most limit executions of the association, most
+// recent first.
+func findAssociationExecutions(ctx context.Context, conn *ssm.Client, associationId string, filters []awstypes.AssociationExecutionFilter, limit int) ([]awstypes.AssociationExecution, error) {
+	input := &ssm.DescribeAssociationExecutionsInput{
+		AssociationId: aws.String(associationId),
+		Filters:       filters,
+		MaxResults:    aws.Int32(int32(min(limit, associationExecutionsPageSize))),
+	}
+
+	var executions []awstypes.AssociationExecution
+
+	pages := ssm.NewDescribeAssociationExecutionsPaginator(conn, input)
+	for pages.HasMorePages() {
+		page, err := pages.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		executions = append(executions, page.AssociationExecutions...)
+
+		if len(executions) >= limit {
+			return executions[:limit], nil
+		}
+	}
+
+	return executions, nil
+}
+
+func findAssociationExecutionTargets(ctx context.Context, conn *ssm.Client, associationId, executionId string, filters []awstypes.AssociationExecutionTargetsFilter) ([]awstypes.AssociationExecutionTarget, error) {
+	input := &ssm.DescribeAssociationExecutionTargetsInput{
+		AssociationId: aws.String(associationId),
+		ExecutionId:   aws.String(executionId),
+		Filters:       filters,
+	}
+
+	var targets []awstypes.AssociationExecutionTarget
+
+	pages := ssm.NewDescribeAssociationExecutionTargetsPaginator(conn, input)
+	for pages.HasMorePages() {
+		page, err := pages.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		targets = append(targets, page.AssociationExecutionTargets...)
+	}
+
+	return targets, nil
+}
+
+func associationExecutionOut(ctx context.Context, execution awstypes.AssociationExecution, targets []awstypes.AssociationExecutionTarget) (AssociationExecutionModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	model := AssociationExecutionModel{
+		AssociationVersion: types.StringPointerValue(execution.AssociationVersion),
+		CreatedTime:        timeOut(execution.CreatedTime),
+		DetailedStatus:     types.StringPointerValue(execution.DetailedStatus),
+		ExecutionId:        types.StringPointerValue(execution.ExecutionId),
+		LastExecutionDate:  timeOut(execution.LastExecutionDate),
+		Status:             types.StringPointerValue(execution.Status),
+	}
+
+	counts, err := parseResourceCountByStatus(aws.ToString(execution.ResourceCountByStatus))
+	if err != nil {
+		diags.AddError("Error reading association execution", err.Error())
+		return model, diags
+	}
+
+	var d diag.Diagnostics
+	model.ResourceCountByStatus, d = types.MapValueFrom(ctx, types.Int64Type, counts)
+	diags.Append(d...)
+
+	result := make([]AssociationExecutionTargetModel, 0, len(targets))
+
+	for _, target := range targets {
+		targetModel := AssociationExecutionTargetModel{
+			DetailedStatus:    types.StringPointerValue(target.DetailedStatus),
+			LastExecutionDate: timeOut(target.LastExecutionDate),
+			OutputSourceId:    types.StringNull(),
+			OutputSourceType:  types.StringNull(),
+			ResourceId:        types.StringPointerValue(target.ResourceId),
+			ResourceType:      types.StringPointerValue(target.ResourceType),
+			Status:            types.StringPointerValue(target.Status),
+		}
+
+		if target.OutputSource != nil {
+			targetModel.OutputSourceId = types.StringPointerValue(target.OutputSource.OutputSourceId)
+			targetModel.OutputSourceType = types.StringPointerValue(target.OutputSource.OutputSourceType)
+		}
+
+		result = append(result, targetModel)
+	}
+
+	model.Targets, d = types.ListValueFrom(ctx, associationExecutionTargetObjectType, result)
+	diags.Append(d...)
+
+	return model, diags
+}
+
+// parseResourceCountByStatus parses the resource counts of an association execution,
+// which AWS returns as a string such as "{Success=2, Failed=1}".
+func parseResourceCountByStatus(s string) (map[string]int64, error) {
+	counts := map[string]int64{}
+
+	s = strings.TrimSpace(s)
+	s = strings.TrimSuffix(strings.TrimPrefix(s, "{"), "}")
+
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		status, count, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("unexpected resource count %q", entry)
+		}
+
+		n, err := strconv.ParseInt(strings.TrimSpace(count), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected resource count %q: %w", entry, err)
+		}
+
+		counts[strings.TrimSpace(status)] = n
+	}
+
+	return counts, nil
+}
@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"maps"
+	"testing"
+)
+
+func TestAccSSMAssociationExecutionsDataSource_basic(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	dataSourceName := "data.automation_aws_ssm_association_executions.test"
+	failedName := "data.automation_aws_ssm_association_executions.failed"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAssociationExecutionsDataSourceConfig_basic(rName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dataSourceName, "association_id", "automation_aws_ssm_association.test", "association_id"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "executions.#"),
+					resource.TestCheckResourceAttr(dataSourceName, "max_executions", "1"),
+					resource.TestCheckResourceAttr(failedName, "executions.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestParseResourceCountByStatus(t *testing.T) {
+	testCases := map[string]struct {
+		input    string
+		expected map[string]int64
+		err      bool
+	}{
+		"empty":    {input: "", expected: map[string]int64{}},
+		"braces":   {input: "{}", expected: map[string]int64{}},
+		"single":   {input: "{Success=1}", expected: map[string]int64{"Success": 1}},
+		"multiple": {input: "{Success=2, Failed=1}", expected: map[string]int64{"Success": 2, "Failed": 1}},
+		"no count": {input: "{Success}", err: true},
+		"bad":      {input: "{Success=many}", err: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := parseResourceCountByStatus(testCase.input)
+
+			if testCase.err {
+				if err == nil {
+					t.Errorf("expected error, got %v", got)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !maps.Equal(got, testCase.expected) {
+				t.Errorf("expected %v, got %v", testCase.expected, got)
+			}
+		})
+	}
+}
+
+func testAccAssociationExecutionsDataSourceConfig_basic(rName string) string {
+	return fmt.Sprintf(`
+resource "automation_aws_ssm_document" "test" {
+  name          = %[1]q
+  document_type = "Command"
+
+  content = jsonencode({
+    schemaVersion = "2.2"
+    description   = "Check ip configuration of a Linux instance."
+    mainSteps = [{
+      action = "aws:runShellScript"
+      name   = "runShellScript"
+      inputs = {
+        runCommand = ["ip addr"]
+      }
+    }]
+  })
+}
+
+resource "automation_aws_ssm_association" "test" {
+  name = automation_aws_ssm_document.test.name
+
+  targets = [{
+    key    = "tag:Name"
+    values = [%[1]q]
+  }]
+}
+
+data "automation_aws_ssm_association_executions" "test" {
+  association_id = automation_aws_ssm_association.test.association_id
+  max_executions = 1
+
+  target_filters = [{
+    key   = "ResourceType"
+    value = "ManagedInstance"
+  }]
+}
+
+data "automation_aws_ssm_association_executions" "failed" {
+  association_id = automation_aws_ssm_association.test.association_id
+
+  execution_filters = [{
+    key   = "Status"
+    value = "Failed"
+  }]
+}
+`, rName)
+}
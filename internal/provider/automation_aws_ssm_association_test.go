@@ -3,13 +3,20 @@ package provider
 import (
 	"context"
 	"fmt"
+	"github.com/YakDriver/regexache"
 	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	awstypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
 	"github.com/coding-ia/terraform-provider-automation/internal/conn"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestAccSSMAssociation_basic(t *testing.T) {
@@ -39,7 +46,8 @@ func TestAccSSMAssociation_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "targets.0.values.#", "1"),
 					resource.TestCheckResourceAttrPair(resourceName, "targets.0.values.0", "aws_instance.test", "id"),
 					resource.TestCheckResourceAttr(resourceName, "parameters.%", "0"),
-					resource.TestCheckResourceAttr(resourceName, "document_version", "$DEFAULT"),
+					resource.TestCheckResourceAttr(resourceName, "document_version", "1"),
+					resource.TestCheckResourceAttr(resourceName, "document_version_selector", "$DEFAULT"),
 					resource.TestCheckResourceAttr(resourceName, "name", rName),
 				),
 			},
@@ -47,9 +55,18 @@ func TestAccSSMAssociation_basic(t *testing.T) {
 				ResourceName:                         resourceName,
 				ImportState:                          true,
 				ImportStateVerify:                    true,
+				ImportStateVerifyIgnore:              []string{"effective_parameters", "parameters"},
 				ImportStateIdFunc:                    testAccSSMAssociationImportStateIdFunc(resourceName),
 				ImportStateVerifyIdentifierAttribute: "association_id",
 			},
+			{
+				ResourceName:                         resourceName,
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateVerifyIgnore:              []string{"effective_parameters", "parameters"},
+				ImportStateIdFunc:                    testAccSSMAssociationImportStateDocumentIdFunc(resourceName),
+				ImportStateVerifyIdentifierAttribute: "association_id",
+			},
 		},
 	})
 }
@@ -81,6 +98,7 @@ func TestAccSSMAssociation_applyOnlyAtCronInterval(t *testing.T) {
 				ResourceName:                         resourceName,
 				ImportState:                          true,
 				ImportStateVerify:                    true,
+				ImportStateVerifyIgnore:              []string{"effective_parameters", "parameters"},
 				ImportStateIdFunc:                    testAccSSMAssociationImportStateIdFunc(resourceName),
 				ImportStateVerifyIdentifierAttribute: "association_id",
 			},
@@ -101,23 +119,26 @@ func TestAccSSMAssociation_withTargets(t *testing.T) {
 	resourceName := "automation_aws_ssm_association.test"
 	oneTarget := `
 
-targets {
-  key    = "tag:Name"
-  values = ["acceptanceTest"]
-}
+targets = [
+  {
+    key    = "tag:Name"
+    values = ["acceptanceTest"]
+  },
+]
 `
 
 	twoTargets := `
 
-targets {
-  key    = "tag:Name"
-  values = ["acceptanceTest"]
-}
-
-targets {
-  key    = "tag:ExtraName"
-  values = ["acceptanceTest"]
-}
+targets = [
+  {
+    key    = "tag:Name"
+    values = ["acceptanceTest"]
+  },
+  {
+    key    = "tag:ExtraName"
+    values = ["acceptanceTest"]
+  },
+]
 `
 
 	resource.ParallelTest(t, resource.TestCase{
@@ -144,6 +165,7 @@ targets {
 				ResourceName:                         resourceName,
 				ImportState:                          true,
 				ImportStateVerify:                    true,
+				ImportStateVerifyIgnore:              []string{"effective_parameters", "parameters"},
 				ImportStateIdFunc:                    testAccSSMAssociationImportStateIdFunc(resourceName),
 				ImportStateVerifyIdentifierAttribute: "association_id",
 			},
@@ -198,6 +220,7 @@ func TestAccSSMAssociation_withParameters(t *testing.T) {
 				ResourceName:                         resourceName,
 				ImportState:                          true,
 				ImportStateVerify:                    true,
+				ImportStateVerifyIgnore:              []string{"effective_parameters", "parameters"},
 				ImportStateIdFunc:                    testAccSSMAssociationImportStateIdFunc(resourceName),
 				ImportStateVerifyIdentifierAttribute: "association_id",
 			},
@@ -241,9 +264,18 @@ func TestAccSSMAssociation_withAssociationName(t *testing.T) {
 				ResourceName:                         resourceName,
 				ImportState:                          true,
 				ImportStateVerify:                    true,
+				ImportStateVerifyIgnore:              []string{"effective_parameters", "parameters"},
 				ImportStateIdFunc:                    testAccSSMAssociationImportStateIdFunc(resourceName),
 				ImportStateVerifyIdentifierAttribute: "association_id",
 			},
+			{
+				ResourceName:                         resourceName,
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateVerifyIgnore:              []string{"effective_parameters", "parameters"},
+				ImportStateIdFunc:                    testAccSSMAssociationImportStateNameIdFunc(resourceName),
+				ImportStateVerifyIdentifierAttribute: "association_id",
+			},
 			{
 				Config: testAccAssociationConfig_basicName(rName, assocName2),
 				Check: resource.ComposeTestCheckFunc(
@@ -286,6 +318,7 @@ func TestAccSSMAssociation_withAssociationNameAndScheduleExpression(t *testing.T
 				ResourceName:                         resourceName,
 				ImportState:                          true,
 				ImportStateVerify:                    true,
+				ImportStateVerifyIgnore:              []string{"effective_parameters", "parameters"},
 				ImportStateIdFunc:                    testAccSSMAssociationImportStateIdFunc(resourceName),
 				ImportStateVerifyIdentifierAttribute: "association_id",
 			},
@@ -328,6 +361,7 @@ func TestAccSSMAssociation_withDocumentVersion(t *testing.T) {
 				ResourceName:                         resourceName,
 				ImportState:                          true,
 				ImportStateVerify:                    true,
+				ImportStateVerifyIgnore:              []string{"effective_parameters", "parameters"},
 				ImportStateIdFunc:                    testAccSSMAssociationImportStateIdFunc(resourceName),
 				ImportStateVerifyIdentifierAttribute: "association_id",
 			},
@@ -363,6 +397,7 @@ func TestAccSSMAssociation_withOutputLocation(t *testing.T) {
 				ResourceName:                         resourceName,
 				ImportState:                          true,
 				ImportStateVerify:                    true,
+				ImportStateVerifyIgnore:              []string{"effective_parameters", "parameters"},
 				ImportStateIdFunc:                    testAccSSMAssociationImportStateIdFunc(resourceName),
 				ImportStateVerifyIdentifierAttribute: "association_id",
 			},
@@ -415,6 +450,7 @@ func TestAccSSMAssociation_withOutputLocation_s3Region(t *testing.T) {
 				ResourceName:                         resourceName,
 				ImportState:                          true,
 				ImportStateVerify:                    true,
+				ImportStateVerifyIgnore:              []string{"effective_parameters", "parameters"},
 				ImportStateIdFunc:                    testAccSSMAssociationImportStateIdFunc(resourceName),
 				ImportStateVerifyIdentifierAttribute: "association_id",
 			},
@@ -431,6 +467,7 @@ func TestAccSSMAssociation_withOutputLocation_s3Region(t *testing.T) {
 				ResourceName:                         resourceName,
 				ImportState:                          true,
 				ImportStateVerify:                    true,
+				ImportStateVerifyIgnore:              []string{"effective_parameters", "parameters"},
 				ImportStateIdFunc:                    testAccSSMAssociationImportStateIdFunc(resourceName),
 				ImportStateVerifyIdentifierAttribute: "association_id",
 			},
@@ -470,12 +507,10 @@ func TestAccSSMAssociation_withOutputLocation_waitForSuccessTimeout(t *testing.T
 				),
 			},
 			{
-				ResourceName:      resourceName,
-				ImportState:       true,
-				ImportStateVerify: true,
-				ImportStateVerifyIgnore: []string{
-					"wait_for_success_timeout_seconds",
-				},
+				ResourceName:                         resourceName,
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateVerifyIgnore:              []string{"effective_parameters", "parameters", "wait_for_success_timeout_seconds"},
 				ImportStateIdFunc:                    testAccSSMAssociationImportStateIdFunc(resourceName),
 				ImportStateVerifyIdentifierAttribute: "association_id",
 			},
@@ -510,7 +545,7 @@ func TestAccSSMAssociation_withAutomationTargetParamName(t *testing.T) {
 				ResourceName:                         resourceName,
 				ImportState:                          true,
 				ImportStateVerify:                    true,
-				ImportStateVerifyIgnore:              []string{"parameters"},
+				ImportStateVerifyIgnore:              []string{"effective_parameters", "parameters"},
 				ImportStateIdFunc:                    testAccSSMAssociationImportStateIdFunc(resourceName),
 				ImportStateVerifyIdentifierAttribute: "association_id",
 			},
@@ -552,6 +587,7 @@ func TestAccSSMAssociation_withScheduleExpression(t *testing.T) {
 				ResourceName:                         resourceName,
 				ImportState:                          true,
 				ImportStateVerify:                    true,
+				ImportStateVerifyIgnore:              []string{"effective_parameters", "parameters"},
 				ImportStateIdFunc:                    testAccSSMAssociationImportStateIdFunc(resourceName),
 				ImportStateVerifyIdentifierAttribute: "association_id",
 			},
@@ -597,6 +633,7 @@ func TestAccSSMAssociation_withComplianceSeverity(t *testing.T) {
 				ResourceName:                         resourceName,
 				ImportState:                          true,
 				ImportStateVerify:                    true,
+				ImportStateVerifyIgnore:              []string{"effective_parameters", "parameters"},
 				ImportStateIdFunc:                    testAccSSMAssociationImportStateIdFunc(resourceName),
 				ImportStateVerifyIdentifierAttribute: "association_id",
 			},
@@ -640,6 +677,7 @@ func TestAccSSMAssociation_rateControl(t *testing.T) {
 				ResourceName:                         resourceName,
 				ImportState:                          true,
 				ImportStateVerify:                    true,
+				ImportStateVerifyIgnore:              []string{"effective_parameters", "parameters"},
 				ImportStateIdFunc:                    testAccSSMAssociationImportStateIdFunc(resourceName),
 				ImportStateVerifyIdentifierAttribute: "association_id",
 			},
@@ -689,75 +727,612 @@ func TestAccSSMAssociation_syncCompliance(t *testing.T) {
 	})
 }
 
-func testAccAssociationConfig_basic(rName string) string {
-	return fmt.Sprintf(`
-data "aws_availability_zones" "available" {
-  state = "available"
+func TestAccSSMAssociation_alarmConfiguration(t *testing.T) {
+	ctx := context.Background()
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "automation_aws_ssm_association.test"
 
-  filter {
-    name   = "opt-in-status"
-    values = ["opt-in-not-required"]
-  }
+	resource.ParallelTest(t, resource.TestCase{
+		ExternalProviders: map[string]resource.ExternalProvider{
+			"aws": {
+				Source:            "hashicorp/aws",
+				VersionConstraint: "5.87.0",
+			},
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckAssociationDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAssociationConfig_alarmConfiguration(rName, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAssociationExists(ctx, resourceName),
+					resource.TestCheckResourceAttr(resourceName, "alarm_configuration.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "alarm_configuration.0.ignore_poll_alarm_failure", "false"),
+					resource.TestCheckResourceAttrPair(resourceName, "alarm_configuration.0.alarms.0", "aws_cloudwatch_metric_alarm.test", "alarm_name"),
+					resource.TestCheckResourceAttr(resourceName, "triggered_alarms.#", "0"),
+				),
+			},
+			{
+				ResourceName:                         resourceName,
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateVerifyIgnore:              []string{"effective_parameters", "parameters"},
+				ImportStateIdFunc:                    testAccSSMAssociationImportStateIdFunc(resourceName),
+				ImportStateVerifyIdentifierAttribute: "association_id",
+			},
+			{
+				Config: testAccAssociationConfig_alarmConfiguration(rName, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAssociationExists(ctx, resourceName),
+					resource.TestCheckResourceAttr(resourceName, "alarm_configuration.0.ignore_poll_alarm_failure", "true"),
+				),
+			},
+		},
+	})
 }
 
-data "aws_ami" "amzn" {
-  most_recent = true
-  owners      = ["amazon"]
+func TestAccSSMAssociation_calendarNames(t *testing.T) {
+	ctx := context.Background()
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "automation_aws_ssm_association.test"
 
-  filter {
-    name   = "name"
-    values = ["amzn2-ami-hvm-*-x86_64-gp2"]
-  }
+	resource.ParallelTest(t, resource.TestCase{
+		ExternalProviders: map[string]resource.ExternalProvider{
+			"aws": {
+				Source:            "hashicorp/aws",
+				VersionConstraint: "5.87.0",
+			},
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckAssociationDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAssociationConfig_calendarNames(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAssociationExists(ctx, resourceName),
+					resource.TestCheckResourceAttr(resourceName, "calendar_names.#", "1"),
+					resource.TestCheckResourceAttrPair(resourceName, "calendar_names.0", "aws_ssm_document.calendar", "arn"),
+				),
+			},
+			{
+				ResourceName:                         resourceName,
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateVerifyIgnore:              []string{"effective_parameters", "parameters"},
+				ImportStateIdFunc:                    testAccSSMAssociationImportStateIdFunc(resourceName),
+				ImportStateVerifyIdentifierAttribute: "association_id",
+			},
+		},
+	})
 }
 
-resource "aws_vpc" "main" {
-  cidr_block = "10.0.0.0/16"
+func TestAccSSMAssociation_scheduleOffsetAndDuration(t *testing.T) {
+	ctx := context.Background()
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "automation_aws_ssm_association.test"
 
-  tags = {
-    Name = %[1]q
-  }
+	resource.ParallelTest(t, resource.TestCase{
+		ExternalProviders: map[string]resource.ExternalProvider{
+			"aws": {
+				Source:            "hashicorp/aws",
+				VersionConstraint: "5.87.0",
+			},
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckAssociationDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccAssociationConfig_scheduleOffsetAndDuration(rName, "rate(1 day)", 2, 4),
+				ExpectError: regexache.MustCompile(`can only be specified when "schedule_expression" is a cron expression`),
+			},
+			{
+				Config: testAccAssociationConfig_scheduleOffsetAndDuration(rName, "cron(0 2 ? * THU#2 *)", 2, 4),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAssociationExists(ctx, resourceName),
+					resource.TestCheckResourceAttr(resourceName, "schedule_offset", "2"),
+					resource.TestCheckResourceAttr(resourceName, "duration", "4"),
+				),
+			},
+			{
+				ResourceName:                         resourceName,
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateVerifyIgnore:              []string{"effective_parameters", "parameters"},
+				ImportStateIdFunc:                    testAccSSMAssociationImportStateIdFunc(resourceName),
+				ImportStateVerifyIdentifierAttribute: "association_id",
+			},
+			{
+				Config: testAccAssociationConfig_scheduleOffsetAndDuration(rName, "cron(0 2 ? * THU#2 *)", 3, 6),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAssociationExists(ctx, resourceName),
+					resource.TestCheckResourceAttr(resourceName, "schedule_offset", "3"),
+					resource.TestCheckResourceAttr(resourceName, "duration", "6"),
+				),
+			},
+		},
+	})
 }
 
-resource "aws_subnet" "first" {
-  vpc_id            = aws_vpc.main.id
-  cidr_block        = "10.0.0.0/24"
-  availability_zone = data.aws_availability_zones.available.names[0]
+func TestAccSSMAssociation_invalidScheduleExpression(t *testing.T) {
+	assocName := acctest.RandomWithPrefix("tf-acc-test")
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.ParallelTest(t, resource.TestCase{
+		ExternalProviders: map[string]resource.ExternalProvider{
+			"aws": {
+				Source:            "hashicorp/aws",
+				VersionConstraint: "5.87.0",
+			},
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccAssociationConfig_nameAndScheduleExpression(rName, assocName, "cron(0 16 * * TUE *)"),
+				ExpectError: regexache.MustCompile(`must use \? in exactly one of the day-of-month and day-of-week fields`),
+			},
+			{
+				Config:      testAccAssociationConfig_nameAndScheduleExpression(rName, assocName, "cron(0/15 * * * ? *)"),
+				ExpectError: regexache.MustCompile(`the minimum interval is 30m0s`),
+			},
+			{
+				Config:      testAccAssociationConfig_nameAndScheduleExpression(rName, assocName, "rate(10 minutes)"),
+				ExpectError: regexache.MustCompile(`the minimum interval is 30m0s`),
+			},
+			{
+				Config:      testAccAssociationConfig_applyOnlyAtCronIntervalScheduleExpression(rName, "rate(1 day)"),
+				ExpectError: regexache.MustCompile(`not a rate expression`),
+			},
+		},
+	})
 }
 
-resource "aws_security_group" "test" {
-  name        = %[1]q
-  description = "foo"
-  vpc_id      = aws_vpc.main.id
+func TestAccSSMAssociation_noOpAutomationTargets(t *testing.T) {
+	ctx := context.Background()
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "automation_aws_ssm_association.test"
+	realTargets := `
+  automation_target_parameter_name = "InstanceId"
 
-  ingress {
-    protocol    = "icmp"
-    from_port   = -1
-    to_port     = -1
-    cidr_blocks = ["0.0.0.0/0"]
-  }
-}
+  targets = [
+    {
+      key    = "ParameterValues"
+      values = ["i-00000000000000000"]
+    },
+  ]
+`
 
-resource "aws_instance" "test" {
-  ami                    = data.aws_ami.amzn.image_id
-  availability_zone      = data.aws_availability_zones.available.names[0]
-  instance_type          = "t2.micro"
-  vpc_security_group_ids = [aws_security_group.test.id]
-  subnet_id              = aws_subnet.first.id
+	resource.ParallelTest(t, resource.TestCase{
+		ExternalProviders: map[string]resource.ExternalProvider{
+			"aws": {
+				Source:            "hashicorp/aws",
+				VersionConstraint: "5.87.0",
+			},
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckAssociationDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAssociationConfig_automationTargets(rName, ""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAssociationExists(ctx, resourceName),
+					resource.TestCheckResourceAttr(resourceName, "targets.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "targets.0.key", "aws:NoOpAutomationTag"),
+				),
+			},
+			{
+				Config:   testAccAssociationConfig_automationTargets(rName, ""),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccAssociationConfig_automationTargets(rName, realTargets),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAssociationExists(ctx, resourceName),
+					resource.TestCheckResourceAttr(resourceName, "targets.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "targets.0.key", "ParameterValues"),
+				),
+			},
+			{
+				Config: testAccAssociationConfig_automationTargets(rName, ""),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionReplace),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAssociationExists(ctx, resourceName),
+					resource.TestCheckResourceAttr(resourceName, "targets.0.key", "aws:NoOpAutomationTag"),
+				),
+			},
+		},
+	})
+}
 
-  tags = {
-    Name = %[1]q
-  }
+func TestAccSSMAssociation_invalidParameters(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccAssociationConfig_documentParameters(`command = ["echo hello"]`),
+				ExpectError: regexache.MustCompile(`"command": not a parameter of this document`),
+			},
+			{
+				Config:      testAccAssociationConfig_documentParameters(`workingDirectory = ["/tmp"]`),
+				ExpectError: regexache.MustCompile(`missing required parameter "commands"`),
+			},
+			{
+				Config:      testAccAssociationConfig_documentParameters(`commands = ["echo hello"], executionTimeout = ["forever"]`),
+				ExpectError: regexache.MustCompile(`"executionTimeout": value "forever" does not match the allowed pattern`),
+			},
+		},
+	})
 }
 
-resource "aws_ssm_document" "test" {
-  name          = %[1]q
-  document_type = "Command"
+func TestAccSSMAssociation_effectiveParameters(t *testing.T) {
+	ctx := context.Background()
+	resourceName := "automation_aws_ssm_association.test"
 
-  content = <<DOC
-{
-  "schemaVersion": "1.2",
-  "description": "Check ip configuration of a Linux instance.",
-  "parameters": {},
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckAssociationDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAssociationConfig_documentParameters(`commands = ["echo hello"]`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectKnownValue(resourceName, tfjsonpath.New("effective_parameters").AtMapKey("executionTimeout"), knownvalue.ListExact([]knownvalue.Check{
+							knownvalue.StringExact("3600"),
+						})),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAssociationExists(ctx, resourceName),
+					resource.TestCheckResourceAttr(resourceName, "parameters.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "effective_parameters.commands.0", "echo hello"),
+					resource.TestCheckResourceAttr(resourceName, "effective_parameters.executionTimeout.0", "3600"),
+				),
+			},
+			{
+				Config: testAccAssociationConfig_documentParameters(`commands = ["echo hello"], executionTimeout = ["600"]`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAssociationExists(ctx, resourceName),
+					resource.TestCheckResourceAttr(resourceName, "parameters.%", "2"),
+					resource.TestCheckResourceAttr(resourceName, "effective_parameters.executionTimeout.0", "600"),
+				),
+			},
+			{
+				Config:   testAccAssociationConfig_documentParameters(`commands = ["echo hello"], executionTimeout = ["600"]`),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccSSMAssociation_sensitiveParameters(t *testing.T) {
+	ctx := context.Background()
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "automation_aws_ssm_association.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		ExternalProviders: map[string]resource.ExternalProvider{
+			"aws": {
+				Source:            "hashicorp/aws",
+				VersionConstraint: "5.87.0",
+			},
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckAssociationDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAssociationConfig_sensitiveParameters(rName, "echo $TOKEN", 1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAssociationExists(ctx, resourceName),
+					resource.TestCheckResourceAttr(resourceName, "parameters.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "parameters.workingDirectory.0", "/tmp"),
+					resource.TestCheckNoResourceAttr(resourceName, "parameters.commands"),
+					resource.TestCheckNoResourceAttr(resourceName, "sensitive_parameters.%"),
+					resource.TestCheckNoResourceAttr(resourceName, "effective_parameters.commands"),
+					resource.TestCheckResourceAttr(resourceName, "sensitive_parameters_version", "1"),
+				),
+			},
+			{
+				Config:   testAccAssociationConfig_sensitiveParameters(rName, "echo $TOKEN", 1),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccAssociationConfig_sensitiveParameters(rName, "echo $TOKEN updated", 2),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAssociationExists(ctx, resourceName),
+					resource.TestCheckResourceAttr(resourceName, "parameters.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "sensitive_parameters_version", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSSMAssociation_parameterReferences(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccAssociationConfig_documentParameters(fmt.Sprintf(`commands = ["echo {{ssm:/%[1]s/missing}}"]`, rName)),
+				ExpectError: regexache.MustCompile(`references Parameter Store parameter /` + rName + `/missing, which does not exist`),
+			},
+		},
+	})
+}
+
+func TestAccSSMAssociation_runNowTriggers(t *testing.T) {
+	ctx := context.Background()
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "automation_aws_ssm_association.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckAssociationDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAssociationConfig_runNowTriggers(rName, "one"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAssociationExists(ctx, resourceName),
+					resource.TestCheckResourceAttr(resourceName, "association_version", "1"),
+					resource.TestCheckResourceAttr(resourceName, "run_now_triggers.refresh", "one"),
+				),
+			},
+			{
+				Config: testAccAssociationConfig_runNowTriggers(rName, "two"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAssociationExists(ctx, resourceName),
+					resource.TestCheckResourceAttr(resourceName, "association_version", "1"),
+					resource.TestCheckResourceAttr(resourceName, "run_now_triggers.refresh", "two"),
+				),
+			},
+		},
+	})
+}
+
+func TestAssociationDefinitionChanged(t *testing.T) {
+	objectType := tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"association_version": tftypes.String,
+			"max_errors":          tftypes.String,
+			"run_now_triggers":    tftypes.Map{ElementType: tftypes.String},
+			"targets": tftypes.List{ElementType: tftypes.Object{
+				AttributeTypes: map[string]tftypes.Type{
+					"key": tftypes.String,
+				},
+			}},
+		},
+	}
+	targetType := objectType.AttributeTypes["targets"].(tftypes.List).ElementType
+
+	value := func(version any, maxErrors, trigger, targetKey string) tftypes.Value {
+		return tftypes.NewValue(objectType, map[string]tftypes.Value{
+			"association_version": tftypes.NewValue(tftypes.String, version),
+			"max_errors":          tftypes.NewValue(tftypes.String, maxErrors),
+			"run_now_triggers": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+				"refresh": tftypes.NewValue(tftypes.String, trigger),
+			}),
+			"targets": tftypes.NewValue(tftypes.List{ElementType: targetType}, []tftypes.Value{
+				tftypes.NewValue(targetType, map[string]tftypes.Value{
+					"key": tftypes.NewValue(tftypes.String, targetKey),
+				}),
+			}),
+		})
+	}
+
+	state := value("1", "1", "one", "tag:Name")
+
+	testCases := map[string]struct {
+		plan tftypes.Value
+		want bool
+	}{
+		"unchanged":               {plan: state, want: false},
+		"triggers only":           {plan: value(tftypes.UnknownValue, "1", "two", "tag:Name"), want: false},
+		"definition":              {plan: value(tftypes.UnknownValue, "2", "one", "tag:Name"), want: true},
+		"nested definition":       {plan: value(tftypes.UnknownValue, "1", "one", "tag:Env"), want: true},
+		"definition and triggers": {plan: value(tftypes.UnknownValue, "2", "two", "tag:Name"), want: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := associationDefinitionChanged(testCase.plan, state)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got != testCase.want {
+				t.Errorf("expected %t, got %t", testCase.want, got)
+			}
+		})
+	}
+}
+
+func TestAccSSMAssociation_invalidWaitFor(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccAssociationConfig_waitFor(`wait_for = "percentage"`),
+				ExpectError: regexache.MustCompile(`Attribute "wait_for_success_percentage" must be specified`),
+			},
+			{
+				Config:      testAccAssociationConfig_waitFor("wait_for = \"all_targets\"\n  wait_for_success_percentage = 90"),
+				ExpectError: regexache.MustCompile(`Attribute "wait_for_success_percentage" can only be specified`),
+			},
+		},
+	})
+}
+
+func TestAccSSMAssociation_emptyTargets(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "automation_aws_ssm_association" "test" {
+  name    = "AWS-StopEC2Instance"
+  targets = []
+}
+`,
+				ExpectError: regexache.MustCompile(`list must contain at least 1 elements`),
+			},
+		},
+	})
+}
+
+func TestParseAssociationDocumentImportID(t *testing.T) {
+	testCases := map[string]struct {
+		importID                             string
+		documentName, targetKey, targetValue string
+		err                                  bool
+	}{
+		"instance":        {importID: "AWS-RunShellScript/InstanceIds=i-0123456789abcdef0", documentName: "AWS-RunShellScript", targetKey: "InstanceIds", targetValue: "i-0123456789abcdef0"},
+		"slash in key":    {importID: "AWS-RunShellScript/tag:kubernetes.io/cluster=prod", documentName: "AWS-RunShellScript", targetKey: "tag:kubernetes.io/cluster", targetValue: "prod"},
+		"equals in value": {importID: "AWS-RunShellScript/tag:Query=a=b", documentName: "AWS-RunShellScript", targetKey: "tag:Query", targetValue: "a=b"},
+		"shared document": {importID: "arn:aws:ssm:us-east-1:123456789012:document/Shared/tag:Name=web", documentName: "arn:aws:ssm:us-east-1:123456789012:document/Shared", targetKey: "tag:Name", targetValue: "web"},
+		"no separator":    {importID: "AWS-RunShellScript", err: true},
+		"no equals":       {importID: "AWS-RunShellScript/InstanceIds", err: true},
+		"no document":     {importID: "/InstanceIds=i-0123456789abcdef0", err: true},
+		"no value":        {importID: "AWS-RunShellScript/InstanceIds=", err: true},
+		"invalid arn":     {importID: "arn:aws:ssm:us-east-1:123456789012:parameter/Name=web", err: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			documentName, targetKey, targetValue, err := parseAssociationDocumentImportID(testCase.importID)
+
+			if testCase.err {
+				if err == nil {
+					t.Error("expected error")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if documentName != testCase.documentName || targetKey != testCase.targetKey || targetValue != testCase.targetValue {
+				t.Errorf("expected %q %q %q, got %q %q %q", testCase.documentName, testCase.targetKey, testCase.targetValue, documentName, targetKey, targetValue)
+			}
+		})
+	}
+}
+
+func TestAssociationTargetsStatus(t *testing.T) {
+	testCases := map[string]struct {
+		counts     map[string]int32
+		percentage int32
+		want       awstypes.AssociationStatusName
+	}{
+		"no targets reported":         {counts: map[string]int32{}, percentage: 100, want: awstypes.AssociationStatusNamePending},
+		"all succeeded":               {counts: map[string]int32{"Success": 3}, percentage: 100, want: awstypes.AssociationStatusNameSuccess},
+		"skipped counts as succeeded": {counts: map[string]int32{"Success": 2, "Skipped": 1}, percentage: 100, want: awstypes.AssociationStatusNameSuccess},
+		"stragglers":                  {counts: map[string]int32{"Success": 2, "InProgress": 1}, percentage: 100, want: awstypes.AssociationStatusNamePending},
+		"any failed":                  {counts: map[string]int32{"Success": 2, "Pending": 1, "Failed": 1}, percentage: 100, want: awstypes.AssociationStatusNameFailed},
+		"percentage reached":          {counts: map[string]int32{"Success": 9, "Pending": 1}, percentage: 90, want: awstypes.AssociationStatusNameSuccess},
+		"percentage reachable":        {counts: map[string]int32{"Success": 8, "Pending": 1, "Failed": 1}, percentage: 90, want: awstypes.AssociationStatusNamePending},
+		"percentage unreachable":      {counts: map[string]int32{"Success": 8, "Failed": 2}, percentage: 90, want: awstypes.AssociationStatusNameFailed},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := associationTargetsStatus(testCase.counts, testCase.percentage); got != string(testCase.want) {
+				t.Errorf("expected %s, got %s", testCase.want, got)
+			}
+		})
+	}
+}
+
+func testAccAssociationConfig_basic(rName string) string {
+	return fmt.Sprintf(`
+data "aws_availability_zones" "available" {
+  state = "available"
+
+  filter {
+    name   = "opt-in-status"
+    values = ["opt-in-not-required"]
+  }
+}
+
+data "aws_ami" "amzn" {
+  most_recent = true
+  owners      = ["amazon"]
+
+  filter {
+    name   = "name"
+    values = ["amzn2-ami-hvm-*-x86_64-gp2"]
+  }
+}
+
+resource "aws_vpc" "main" {
+  cidr_block = "10.0.0.0/16"
+
+  tags = {
+    Name = %[1]q
+  }
+}
+
+resource "aws_subnet" "first" {
+  vpc_id            = aws_vpc.main.id
+  cidr_block        = "10.0.0.0/24"
+  availability_zone = data.aws_availability_zones.available.names[0]
+}
+
+resource "aws_security_group" "test" {
+  name        = %[1]q
+  description = "foo"
+  vpc_id      = aws_vpc.main.id
+
+  ingress {
+    protocol    = "icmp"
+    from_port   = -1
+    to_port     = -1
+    cidr_blocks = ["0.0.0.0/0"]
+  }
+}
+
+resource "aws_instance" "test" {
+  ami                    = data.aws_ami.amzn.image_id
+  availability_zone      = data.aws_availability_zones.available.names[0]
+  instance_type          = "t2.micro"
+  vpc_security_group_ids = [aws_security_group.test.id]
+  subnet_id              = aws_subnet.first.id
+
+  tags = {
+    Name = %[1]q
+  }
+}
+
+resource "aws_ssm_document" "test" {
+  name          = %[1]q
+  document_type = "Command"
+
+  content = <<DOC
+{
+  "schemaVersion": "1.2",
+  "description": "Check ip configuration of a Linux instance.",
+  "parameters": {},
   "runtimeConfig": {
     "aws:runShellScript": {
       "properties": [
@@ -778,10 +1353,12 @@ DOC
 resource "automation_aws_ssm_association" "test" {
   name        = %[1]q
 
-  targets {
-    key    = "InstanceIds"
-    values = [aws_instance.test.id]
-  }
+  targets = [
+    {
+      key    = "InstanceIds"
+      values = [aws_instance.test.id]
+    },
+  ]
 
 }
 `, rName)
@@ -820,10 +1397,12 @@ resource "automation_aws_ssm_association" "test" {
   schedule_expression         = "cron(0 16 ? * TUE *)"
   apply_only_at_cron_interval = %[2]t
 
-  targets {
-    key    = "tag:Name"
-    values = ["acceptanceTest"]
-  }
+  targets = [
+    {
+      key    = "tag:Name"
+      values = ["acceptanceTest"]
+    },
+  ]
 }
 `, rName, applyOnlyAtCronInterval)
 }
@@ -905,10 +1484,12 @@ resource "automation_aws_ssm_association" "test" {
     Directory = [ "myWorkSpaceUpdated" ]
   }
 
-  targets {
-    key    = "tag:Name"
-    values = ["acceptanceTest"]
-  }
+  targets = [
+    {
+      key    = "tag:Name"
+      values = ["acceptanceTest"]
+    },
+  ]
 }
 `, rName)
 }
@@ -955,10 +1536,12 @@ resource "automation_aws_ssm_association" "test" {
     Directory = [ "myWorkSpace" ]
   }
 
-  targets {
-    key    = "tag:Name"
-    values = ["acceptanceTest"]
-  }
+  targets = [
+    {
+      key    = "tag:Name"
+      values = ["acceptanceTest"]
+    },
+  ]
 }
 `, rName)
 }
@@ -995,10 +1578,12 @@ resource "automation_aws_ssm_association" "test" {
   name             = aws_ssm_document.test.name
   association_name = %[2]q
 
-  targets {
-    key    = "tag:Name"
-    values = ["acceptanceTest"]
-  }
+  targets = [
+    {
+      key    = "tag:Name"
+      values = ["acceptanceTest"]
+    },
+  ]
 }
 `, rName, assocName)
 }
@@ -1036,10 +1621,12 @@ resource "automation_aws_ssm_association" "test" {
   name                = aws_ssm_document.test.name
   schedule_expression = %[3]q
 
-  targets {
-    key    = "tag:Name"
-    values = ["acceptanceTest"]
-  }
+  targets = [
+    {
+      key    = "tag:Name"
+      values = ["acceptanceTest"]
+    },
+  ]
 }
 `, rName, associationName, scheduleExpression)
 }
@@ -1076,10 +1663,12 @@ resource "automation_aws_ssm_association" "test" {
   name             = %[1]q
   document_version = aws_ssm_document.test.latest_version
 
-  targets {
-    key    = "tag:Name"
-    values = ["acceptanceTest"]
-  }
+  targets = [
+    {
+      key    = "tag:Name"
+      values = ["acceptanceTest"]
+    },
+  ]
 }
 `, rName)
 }
@@ -1120,10 +1709,12 @@ DOC
 resource "automation_aws_ssm_association" "test" {
   name = aws_ssm_document.test.name
 
-  targets {
-    key    = "tag:Name"
-    values = ["acceptanceTest"]
-  }
+  targets = [
+    {
+      key    = "tag:Name"
+      values = ["acceptanceTest"]
+    },
+  ]
 
   output_location {
     s3_bucket_name = aws_s3_bucket.output_location.id
@@ -1174,10 +1765,12 @@ DOC
 resource "automation_aws_ssm_association" "test" {
   name = aws_ssm_document.test.name
 
-  targets {
-    key    = "tag:Name"
-    values = ["acceptanceTest"]
-  }
+  targets = [
+    {
+      key    = "tag:Name"
+      values = ["acceptanceTest"]
+    },
+  ]
 
   output_location {
     s3_bucket_name = aws_s3_bucket.output_location_updated.id
@@ -1228,10 +1821,12 @@ DOC
 resource "automation_aws_ssm_association" "test" {
   name = aws_ssm_document.test.name
 
-  targets {
-    key    = "tag:Name"
-    values = ["acceptanceTest"]
-  }
+  targets = [
+    {
+      key    = "tag:Name"
+      values = ["acceptanceTest"]
+    },
+  ]
 
   output_location {
     s3_bucket_name = aws_s3_bucket.output_location_updated.id
@@ -1282,10 +1877,12 @@ func testAccAssociationConfig_outputLocationS3Region(rName string) string {
 resource "automation_aws_ssm_association" "test" {
   name = aws_ssm_document.test.name
 
-  targets {
-    key    = "tag:Name"
-    values = ["acceptanceTest"]
-  }
+  targets = [
+    {
+      key    = "tag:Name"
+      values = ["acceptanceTest"]
+    },
+  ]
 
   output_location {
     s3_bucket_name = aws_s3_bucket.test.id
@@ -1302,10 +1899,12 @@ func testAccAssociationConfig_outputLocationUpdateS3Region(rName string) string
 resource "automation_aws_ssm_association" "test" {
   name = aws_ssm_document.test.name
 
-  targets {
-    key    = "tag:Name"
-    values = ["acceptanceTest"]
-  }
+  targets = [
+    {
+      key    = "tag:Name"
+      values = ["acceptanceTest"]
+    },
+  ]
 
   output_location {
     s3_bucket_name = aws_s3_bucket.test.id
@@ -1322,10 +1921,12 @@ func testAccAssociationConfig_outputLocationNoS3Region(rName string) string {
 resource "automation_aws_ssm_association" "test" {
   name = aws_ssm_document.test.name
 
-  targets {
-    key    = "tag:Name"
-    values = ["acceptanceTest"]
-  }
+  targets = [
+    {
+      key    = "tag:Name"
+      values = ["acceptanceTest"]
+    },
+  ]
 
   output_location {
     s3_bucket_name = aws_s3_bucket.test.id
@@ -1341,10 +1942,12 @@ func testAccAssociationConfig_outputLocationAndWaitForSuccess(rName string) stri
 resource "automation_aws_ssm_association" "test" {
   name = aws_ssm_document.test.name
 
-  targets {
-    key    = "tag:Name"
-    values = ["acceptanceTest"]
-  }
+  targets = [
+    {
+      key    = "tag:Name"
+      values = ["acceptanceTest"]
+    },
+  ]
 
   output_location {
     s3_bucket_name = aws_s3_bucket.test.id
@@ -1458,10 +2061,12 @@ resource "automation_aws_ssm_association" "test" {
     Directory            = [ "myWorkSpace" ]
   }
 
-  targets {
-    key    = "tag:myTagName"
-    values = ["myTagValue"]
-  }
+  targets = [
+    {
+      key    = "tag:myTagName"
+      values = ["myTagValue"]
+    },
+  ]
 
   schedule_expression = "rate(60 minutes)"
 }
@@ -1500,10 +2105,12 @@ resource "automation_aws_ssm_association" "test" {
   name                = aws_ssm_document.test.name
   schedule_expression = "cron(0 16 ? * TUE *)"
 
-  targets {
-    key    = "tag:Name"
-    values = ["acceptanceTest"]
-  }
+  targets = [
+    {
+      key    = "tag:Name"
+      values = ["acceptanceTest"]
+    },
+  ]
 }
 `, rName)
 }
@@ -1540,10 +2147,12 @@ resource "automation_aws_ssm_association" "test" {
   name                = aws_ssm_document.test.name
   schedule_expression = "cron(0 16 ? * WED *)"
 
-  targets {
-    key    = "tag:Name"
-    values = ["acceptanceTest"]
-  }
+  targets = [
+    {
+      key    = "tag:Name"
+      values = ["acceptanceTest"]
+    },
+  ]
 }
 `, rName)
 }
@@ -1581,10 +2190,12 @@ resource "automation_aws_ssm_association" "test" {
   association_name    = %[2]q
   compliance_severity = %[3]q
 
-  targets {
-    key    = "tag:Name"
-    values = ["acceptanceTest"]
-  }
+  targets = [
+    {
+      key    = "tag:Name"
+      values = ["acceptanceTest"]
+    },
+  ]
 }
 `, rName, assocName, compSeverity)
 }
@@ -1622,10 +2233,12 @@ resource "automation_aws_ssm_association" "test" {
   max_concurrency = %[2]q
   max_errors      = %[2]q
 
-  targets {
-    key    = "tag:Name"
-    values = ["acceptanceTest"]
-  }
+  targets = [
+    {
+      key    = "tag:Name"
+      values = ["acceptanceTest"]
+    },
+  ]
 }
 `, rName, rate)
 }
@@ -1634,10 +2247,12 @@ func testAccAssociationSyncComplianceConfig(rName, syncCompliance string) string
 	return fmt.Sprintf(`
 resource "automation_aws_ssm_association" "test" {
   name = %[1]q
-  targets {
-    key    = "InstanceIds"
-    values = ["*"]
-  }
+  targets = [
+    {
+      key    = "InstanceIds"
+      values = ["*"]
+    },
+  ]
   apply_only_at_cron_interval = false
   sync_compliance             = %[2]q
   parameters = {
@@ -1654,6 +2269,317 @@ resource "automation_aws_ssm_association" "test" {
 `, rName, syncCompliance)
 }
 
+func testAccAssociationConfig_alarmConfiguration(rName string, ignorePollAlarmFailure bool) string {
+	return fmt.Sprintf(`
+resource "aws_cloudwatch_metric_alarm" "test" {
+  alarm_name          = %[1]q
+  comparison_operator = "GreaterThanOrEqualToThreshold"
+  evaluation_periods  = 2
+  metric_name         = "CPUUtilization"
+  namespace           = "AWS/EC2"
+  period              = 120
+  statistic           = "Average"
+  threshold           = 80
+}
+
+resource "aws_ssm_document" "test" {
+  name          = %[1]q
+  document_type = "Command"
+
+  content = <<DOC
+{
+  "schemaVersion": "1.2",
+  "description": "Check ip configuration of a Linux instance.",
+  "parameters": {},
+  "runtimeConfig": {
+    "aws:runShellScript": {
+      "properties": [
+        {
+          "id": "0.aws:runShellScript",
+          "runCommand": [
+            "ifconfig"
+          ]
+        }
+      ]
+    }
+  }
+}
+DOC
+
+}
+
+resource "automation_aws_ssm_association" "test" {
+  name = aws_ssm_document.test.name
+
+  targets = [
+    {
+      key    = "tag:Name"
+      values = ["acceptanceTest"]
+    },
+  ]
+
+  alarm_configuration {
+    alarms                    = [aws_cloudwatch_metric_alarm.test.alarm_name]
+    ignore_poll_alarm_failure = %[2]t
+  }
+}
+`, rName, ignorePollAlarmFailure)
+}
+
+func testAccAssociationConfig_calendarNames(rName string) string {
+	return fmt.Sprintf(`
+resource "aws_ssm_document" "calendar" {
+  name            = "%[1]s-calendar"
+  document_type   = "ChangeCalendar"
+  document_format = "TEXT"
+
+  content = <<DOC
+BEGIN:VCALENDAR
+PRODID:-//AWS//Change Calendar 1.0//EN
+VERSION:2.0
+X-CALENDAR-TYPE:DEFAULT_OPEN
+X-WR-CALDESC:test
+END:VCALENDAR
+DOC
+
+}
+
+resource "aws_ssm_document" "test" {
+  name          = %[1]q
+  document_type = "Command"
+
+  content = <<DOC
+{
+  "schemaVersion": "1.2",
+  "description": "Check ip configuration of a Linux instance.",
+  "parameters": {},
+  "runtimeConfig": {
+    "aws:runShellScript": {
+      "properties": [
+        {
+          "id": "0.aws:runShellScript",
+          "runCommand": [
+            "ifconfig"
+          ]
+        }
+      ]
+    }
+  }
+}
+DOC
+
+}
+
+resource "automation_aws_ssm_association" "test" {
+  name           = aws_ssm_document.test.name
+  calendar_names = [aws_ssm_document.calendar.arn]
+
+  targets = [
+    {
+      key    = "tag:Name"
+      values = ["acceptanceTest"]
+    },
+  ]
+}
+`, rName)
+}
+
+func testAccAssociationConfig_scheduleOffsetAndDuration(rName, scheduleExpression string, scheduleOffset, duration int) string {
+	return fmt.Sprintf(`
+resource "aws_ssm_document" "test" {
+  name          = %[1]q
+  document_type = "Command"
+
+  content = <<DOC
+{
+  "schemaVersion": "1.2",
+  "description": "Check ip configuration of a Linux instance.",
+  "parameters": {},
+  "runtimeConfig": {
+    "aws:runShellScript": {
+      "properties": [
+        {
+          "id": "0.aws:runShellScript",
+          "runCommand": [
+            "ifconfig"
+          ]
+        }
+      ]
+    }
+  }
+}
+DOC
+
+}
+
+resource "automation_aws_ssm_association" "test" {
+  name                        = aws_ssm_document.test.name
+  schedule_expression         = %[2]q
+  schedule_offset             = %[3]d
+  duration                    = %[4]d
+  apply_only_at_cron_interval = true
+
+  targets = [
+    {
+      key    = "tag:Name"
+      values = ["acceptanceTest"]
+    },
+  ]
+}
+`, rName, scheduleExpression, scheduleOffset, duration)
+}
+
+func testAccAssociationConfig_applyOnlyAtCronIntervalScheduleExpression(rName, scheduleExpression string) string {
+	return fmt.Sprintf(`
+resource "automation_aws_ssm_association" "test" {
+  name                        = %[1]q
+  schedule_expression         = %[2]q
+  apply_only_at_cron_interval = true
+
+  targets = [
+    {
+      key    = "tag:Name"
+      values = ["acceptanceTest"]
+    },
+  ]
+}
+`, rName, scheduleExpression)
+}
+
+func testAccAssociationConfig_automationTargets(rName, targetsStr string) string {
+	return fmt.Sprintf(`
+resource "aws_ssm_document" "test" {
+  name          = %[1]q
+  document_type = "Automation"
+
+  content = <<DOC
+{
+  "description": "Systems Manager Automation Demo",
+  "schemaVersion": "0.3",
+  "parameters": {
+    "InstanceId": {
+      "type": "String",
+      "default": ""
+    }
+  },
+  "mainSteps": [
+    {
+      "name": "sleep",
+      "action": "aws:sleep",
+      "inputs": {
+        "Duration": "PT1S"
+      }
+    }
+  ]
+}
+DOC
+
+}
+
+resource "automation_aws_ssm_association" "test" {
+  name                = aws_ssm_document.test.name
+  schedule_expression = "rate(1 day)"
+  %[2]s
+}
+`, rName, targetsStr)
+}
+
+func testAccAssociationConfig_documentParameters(parameters string) string {
+	return fmt.Sprintf(`
+resource "automation_aws_ssm_association" "test" {
+  name = "AWS-RunShellScript"
+
+  parameters = {
+    %[1]s
+  }
+
+  targets = [
+    {
+      key    = "tag:Name"
+      values = ["acceptanceTest"]
+    },
+  ]
+}
+`, parameters)
+}
+
+func testAccAssociationConfig_runNowTriggers(rName, trigger string) string {
+	return fmt.Sprintf(`
+resource "automation_aws_ssm_association" "test" {
+  name             = "AWS-RunShellScript"
+  association_name = %[1]q
+
+  targets = [
+    {
+      key    = "tag:Name"
+      values = [%[1]q]
+    },
+  ]
+
+  parameters = {
+    commands = ["echo hello"]
+  }
+
+  run_now_triggers = {
+    refresh = %[2]q
+  }
+}
+`, rName, trigger)
+}
+
+func testAccAssociationConfig_waitFor(waitFor string) string {
+	return fmt.Sprintf(`
+resource "automation_aws_ssm_association" "test" {
+  name = "AWS-RunShellScript"
+
+  targets = [
+    {
+      key    = "tag:Name"
+      values = ["acceptanceTest"]
+    },
+  ]
+
+  parameters = {
+    commands = ["echo hello"]
+  }
+
+  %[1]s
+  wait_for_success_timeout_seconds = 600
+}
+`, waitFor)
+}
+
+func testAccAssociationConfig_sensitiveParameters(rName, command string, version int) string {
+	return fmt.Sprintf(`
+resource "aws_ssm_parameter" "test" {
+  name  = "/%[1]s/token"
+  type  = "SecureString"
+  value = "secret"
+}
+
+resource "automation_aws_ssm_association" "test" {
+  name = "AWS-RunShellScript"
+
+  parameters = {
+    workingDirectory = ["/tmp"]
+  }
+
+  sensitive_parameters = {
+    commands = ["TOKEN={{ssm-secure:${aws_ssm_parameter.test.name}}} && %[2]s"]
+  }
+
+  sensitive_parameters_version = %[3]d
+
+  targets = [
+    {
+      key    = "tag:Name"
+      values = ["acceptanceTest"]
+    },
+  ]
+}
+`, rName, command, version)
+}
+
 func testAccCheckAssociationExists(ctx context.Context, n string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -1741,6 +2667,28 @@ func testAccSSMAssociationImportStateIdFunc(resourceName string) resource.Import
 	}
 }
 
+func testAccSSMAssociationImportStateNameIdFunc(resourceName string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmt.Errorf("Not found: %s", resourceName)
+		}
+
+		return "name:" + rs.Primary.Attributes["association_name"], nil
+	}
+}
+
+func testAccSSMAssociationImportStateDocumentIdFunc(resourceName string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmt.Errorf("Not found: %s", resourceName)
+		}
+
+		return fmt.Sprintf("document:%s/%s=%s", rs.Primary.Attributes["name"], rs.Primary.Attributes["targets.0.key"], rs.Primary.Attributes["targets.0.values.0"]), nil
+	}
+}
+
 func getProviderMeta(ctx context.Context) Meta {
 	provider := New("")()
 	p := provider.(*AutomationProvider)
@@ -1751,3 +2699,33 @@ func getProviderMeta(ctx context.Context) Meta {
 
 	return p.Meta
 }
+
+func TestRemainingWaitTimeout(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := map[string]struct {
+		deadline time.Time
+		want     time.Duration
+	}{
+		"time left": {
+			deadline: now.Add(5 * time.Minute),
+			want:     5 * time.Minute,
+		},
+		"less than the minimum left": {
+			deadline: now.Add(time.Second),
+			want:     associationMinimumWaitTimeout,
+		},
+		"deadline passed": {
+			deadline: now.Add(-time.Minute),
+			want:     associationMinimumWaitTimeout,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := remainingWaitTimeout(testCase.deadline, now); got != testCase.want {
+				t.Errorf("expected %s, got %s", testCase.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,327 @@
+package provider
+
+import (
+	"context"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	awstypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+)
+
+var _ datasource.DataSource = &AWSSSMAssociationVersionsDataSource{}
+var _ datasource.DataSourceWithConfigure = &AWSSSMAssociationVersionsDataSource{}
+
+type AWSSSMAssociationVersionsDataSource struct {
+	Meta Meta
+}
+
+type AWSSSMAssociationVersionsDataSourceModel struct {
+	AssociationId types.String `tfsdk:"association_id"`
+	Versions      types.List   `tfsdk:"versions"`
+}
+
+type AssociationVersionModel struct {
+	AssociationName    types.String    `tfsdk:"association_name"`
+	AssociationVersion types.String    `tfsdk:"association_version"`
+	Changes            types.List      `tfsdk:"changes"`
+	ComplianceSeverity types.String    `tfsdk:"compliance_severity"`
+	CreatedDate        types.String    `tfsdk:"created_date"`
+	DocumentVersion    types.String    `tfsdk:"document_version"`
+	MaxConcurrency     types.String    `tfsdk:"max_concurrency"`
+	MaxErrors          types.String    `tfsdk:"max_errors"`
+	Name               types.String    `tfsdk:"name"`
+	Parameters         ParametersValue `tfsdk:"parameters"`
+	ScheduleExpression types.String    `tfsdk:"schedule_expression"`
+	ScheduleOffset     types.Int32     `tfsdk:"schedule_offset"`
+	SyncCompliance     types.String    `tfsdk:"sync_compliance"`
+	Targets            TargetsValue    `tfsdk:"targets"`
+}
+
+var associationVersionObjectType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"association_name":    types.StringType,
+		"association_version": types.StringType,
+		"changes":             types.ListType{ElemType: types.StringType},
+		"compliance_severity": types.StringType,
+		"created_date":        types.StringType,
+		"document_version":    types.StringType,
+		"max_concurrency":     types.StringType,
+		"max_errors":          types.StringType,
+		"name":                types.StringType,
+		"parameters":          NewParametersType(),
+		"schedule_expression": types.StringType,
+		"schedule_offset":     types.Int32Type,
+		"sync_compliance":     types.StringType,
+		"targets":             NewTargetsType(),
+	},
+}
+
+func newAWSSSMAssociationVersionsDataSource() datasource.DataSource {
+	return &AWSSSMAssociationVersionsDataSource{}
+}
+
+func (a *AWSSSMAssociationVersionsDataSource) Configure(_ context.Context, request datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if request.ProviderData == nil {
+		return
+	}
+
+	a.Meta = request.ProviderData.(Meta)
+}
+
+func (a *AWSSSMAssociationVersionsDataSource) Metadata(_ context.Context, request datasource.MetadataRequest, response *datasource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_aws_ssm_association_versions"
+}
+
+func (a *AWSSSMAssociationVersionsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, response *datasource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		MarkdownDescription: "Lists every version of an SSM association, oldest first, with the settings that changed in each version.",
+		Attributes: map[string]schema.Attribute{
+			"association_id": schema.StringAttribute{
+				Description: "The ID of the association.",
+				Required:    true,
+			},
+			"versions": schema.ListNestedAttribute{
+				Description: "The versions of the association.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"association_name": schema.StringAttribute{
+							Description: "The name of the association.",
+							Computed:    true,
+						},
+						"association_version": schema.StringAttribute{
+							Description: "The version of the association.",
+							Computed:    true,
+						},
+						"changes": schema.ListAttribute{
+							MarkdownDescription: "The settings that changed from the previous version, e.g. `schedule_expression` or `parameters.Message`.  Empty for the first version.",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+						"compliance_severity": schema.StringAttribute{
+							Description: "The severity of the association compliance.",
+							Computed:    true,
+						},
+						"created_date": schema.StringAttribute{
+							Description: "The time the version was created.",
+							Computed:    true,
+						},
+						"document_version": schema.StringAttribute{
+							Description: "The document version the association runs, a number, $DEFAULT or $LATEST.",
+							Computed:    true,
+						},
+						"max_concurrency": schema.StringAttribute{
+							Description: "The maximum number of targets running the association at the same time.",
+							Computed:    true,
+						},
+						"max_errors": schema.StringAttribute{
+							Description: "The number of errors allowed before the association stops running.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "The name of the SSM document.",
+							Computed:    true,
+						},
+						"parameters": schema.MapAttribute{
+							Description: "The parameters of the version.  Sensitive, as older versions may hold values that are now set through sensitive_parameters.",
+							Computed:    true,
+							Sensitive:   true,
+							CustomType:  NewParametersType(),
+							ElementType: types.ListType{ElemType: types.StringType},
+						},
+						"schedule_expression": schema.StringAttribute{
+							Description: "The schedule of the version.",
+							Computed:    true,
+						},
+						"schedule_offset": schema.Int32Attribute{
+							Description: "The number of days to wait after the scheduled day to run the association.",
+							Computed:    true,
+						},
+						"sync_compliance": schema.StringAttribute{
+							Description: "The compliance mode of the association, AUTO or MANUAL.",
+							Computed:    true,
+						},
+						"targets": schema.ListNestedAttribute{
+							Description: "The targets of the version.",
+							Computed:    true,
+							CustomType:  NewTargetsType(),
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"key": schema.StringAttribute{
+										Computed: true,
+									},
+									"values": schema.ListAttribute{
+										Computed:    true,
+										ElementType: types.StringType,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (a *AWSSSMAssociationVersionsDataSource) Read(ctx context.Context, request datasource.ReadRequest, response *datasource.ReadResponse) {
+	var data AWSSSMAssociationVersionsDataSourceModel
+
+	response.Diagnostics.Append(request.Config.Get(ctx, &data)...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	versions, err := findAssociationVersions(ctx, a.Meta.AWSClient.SSMClient, data.AssociationId.ValueString())
+	if err != nil {
+		response.Diagnostics.AddError("Error reading association versions", err.Error())
+		return
+	}
+
+	result := make([]AssociationVersionModel, 0, len(versions))
+
+	for i, version := range versions {
+		var changes []string
+		if i > 0 {
+			changes = associationVersionChanges(versions[i-1], version)
+		}
+
+		model, diags := associationVersionOut(ctx, version, changes)
+		response.Diagnostics.Append(diags...)
+
+		result = append(result, model)
+	}
+
+	var diags diag.Diagnostics
+	data.Versions, diags = types.ListValueFrom(ctx, associationVersionObjectType, result)
+	response.Diagnostics.Append(diags...)
+
+	response.Diagnostics.Append(response.State.Set(ctx, &data)...)
+}
+
+// findAssociationVersions returns the versions of an association, oldest first.
+func findAssociationVersions(ctx context.Context, conn *ssm.Client, id string) ([]awstypes.AssociationVersionInfo, error) {
+	input := &ssm.ListAssociationVersionsInput{
+		AssociationId: aws.String(id),
+	}
+
+	var versions []awstypes.AssociationVersionInfo
+
+	pages := ssm.NewListAssociationVersionsPaginator(conn, input)
+	for pages.HasMorePages() {
+		page, err := pages.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		versions = append(versions, page.AssociationVersions...)
+	}
+
+	sortAssociationVersions(versions)
+
+	return versions, nil
+}
+
+func sortAssociationVersions(versions []awstypes.AssociationVersionInfo) {
+	slices.SortStableFunc(versions, func(a, b awstypes.AssociationVersionInfo) int {
+		x, _ := strconv.Atoi(aws.ToString(a.AssociationVersion))
+		y, _ := strconv.Atoi(aws.ToString(b.AssociationVersion))
+
+		return x - y
+	})
+}
+
+// associationVersionChanges returns the settings that differ between two versions of an
+// association, named after the association resource attributes.  Parameters are listed
+// by key so that values do not have to be compared by the reader.  Targets are compared
+// regardless of order.  AssociationVersionInfo has no alarm configuration, changes to it
+// are not listed.
+func associationVersionChanges(previous, current awstypes.AssociationVersionInfo) []string {
+	var changes []string
+
+	compare := func(name string, a, b any) {
+		if !reflect.DeepEqual(a, b) {
+			changes = append(changes, name)
+		}
+	}
+
+	compare("apply_only_at_cron_interval", previous.ApplyOnlyAtCronInterval, current.ApplyOnlyAtCronInterval)
+	compare("association_name", aws.ToString(previous.AssociationName), aws.ToString(current.AssociationName))
+	compare("calendar_names", previous.CalendarNames, current.CalendarNames)
+	compare("compliance_severity", previous.ComplianceSeverity, current.ComplianceSeverity)
+	compare("document_version", aws.ToString(previous.DocumentVersion), aws.ToString(current.DocumentVersion))
+	compare("duration", aws.ToInt32(previous.Duration), aws.ToInt32(current.Duration))
+	compare("max_concurrency", aws.ToString(previous.MaxConcurrency), aws.ToString(current.MaxConcurrency))
+	compare("max_errors", aws.ToString(previous.MaxErrors), aws.ToString(current.MaxErrors))
+	compare("name", aws.ToString(previous.Name), aws.ToString(current.Name))
+	compare("output_location", previous.OutputLocation, current.OutputLocation)
+	compare("schedule_expression", aws.ToString(previous.ScheduleExpression), aws.ToString(current.ScheduleExpression))
+	compare("schedule_offset", aws.ToInt32(previous.ScheduleOffset), aws.ToInt32(current.ScheduleOffset))
+	compare("sync_compliance", previous.SyncCompliance, current.SyncCompliance)
+	compare("target_locations", previous.TargetLocations, current.TargetLocations)
+	compare("target_maps", previous.TargetMaps, current.TargetMaps)
+	compare("targets", canonicalTargets(previous.Targets), canonicalTargets(current.Targets))
+
+	keys := slices.Sorted(maps.Keys(previous.Parameters))
+	for _, key := range slices.Sorted(maps.Keys(current.Parameters)) {
+		if _, ok := previous.Parameters[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		previousValue, previousOk := previous.Parameters[key]
+		currentValue, currentOk := current.Parameters[key]
+
+		if previousOk != currentOk || !slices.Equal(previousValue, currentValue) {
+			changes = append(changes, "parameters."+key)
+		}
+	}
+
+	slices.Sort(changes)
+
+	return changes
+}
+
+func associationVersionOut(ctx context.Context, version awstypes.AssociationVersionInfo, changes []string) (AssociationVersionModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if changes == nil {
+		changes = []string{}
+	}
+
+	model := AssociationVersionModel{
+		AssociationName:    types.StringPointerValue(version.AssociationName),
+		AssociationVersion: types.StringPointerValue(version.AssociationVersion),
+		CreatedDate:        timeOut(version.CreatedDate),
+		DocumentVersion:    types.StringPointerValue(version.DocumentVersion),
+		MaxConcurrency:     types.StringPointerValue(version.MaxConcurrency),
+		MaxErrors:          types.StringPointerValue(version.MaxErrors),
+		Name:               types.StringPointerValue(version.Name),
+		Parameters:         parametersOut(version.Parameters),
+		ScheduleExpression: types.StringPointerValue(version.ScheduleExpression),
+		ScheduleOffset:     types.Int32PointerValue(version.ScheduleOffset),
+	}
+
+	SetFrameworkFromString(&model.ComplianceSeverity, string(version.ComplianceSeverity), true)
+	SetFrameworkFromString(&model.SyncCompliance, string(version.SyncCompliance), true)
+
+	var d diag.Diagnostics
+	model.Changes, d = types.ListValueFrom(ctx, types.StringType, changes)
+	diags.Append(d...)
+
+	model.Targets, d = targetsOut(ctx, version.Targets)
+	diags.Append(d...)
+
+	return model, diags
+}
@@ -0,0 +1,194 @@
+package provider
+
+import (
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awstypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"maps"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestAccSSMAssociationVersionsDataSource_basic(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	dataSourceName := "data.automation_aws_ssm_association_versions.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAssociationVersionsDataSourceConfig_basic(rName, "rate(1 day)", "one"),
+			},
+			{
+				Config: testAccAssociationVersionsDataSourceConfig_basic(rName, "rate(2 days)", "two"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "versions.#", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "versions.0.association_version", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "versions.0.schedule_expression", "rate(1 day)"),
+					resource.TestCheckResourceAttr(dataSourceName, "versions.0.changes.#", "0"),
+					resource.TestCheckResourceAttr(dataSourceName, "versions.1.association_version", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "versions.1.schedule_expression", "rate(2 days)"),
+					resource.TestCheckResourceAttr(dataSourceName, "versions.1.name", rName),
+					resource.TestCheckResourceAttr(dataSourceName, "versions.1.targets.0.key", "tag:Name"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "versions.1.created_date"),
+					resource.TestCheckResourceAttr(dataSourceName, "versions.1.changes.#", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "versions.1.changes.0", "parameters.Message"),
+					resource.TestCheckResourceAttr(dataSourceName, "versions.1.changes.1", "schedule_expression"),
+				),
+			},
+		},
+	})
+}
+
+func TestSortAssociationVersions(t *testing.T) {
+	versions := []awstypes.AssociationVersionInfo{
+		{AssociationVersion: aws.String("10")},
+		{AssociationVersion: aws.String("2")},
+		{AssociationVersion: aws.String("1")},
+	}
+
+	sortAssociationVersions(versions)
+
+	var got []string
+	for _, version := range versions {
+		got = append(got, aws.ToString(version.AssociationVersion))
+	}
+
+	if want := []string{"1", "2", "10"}; !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestAssociationVersionChanges(t *testing.T) {
+	base := awstypes.AssociationVersionInfo{
+		AssociationVersion: aws.String("1"),
+		CreatedDate:        aws.Time(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+		DocumentVersion:    aws.String("$DEFAULT"),
+		Name:               aws.String("example"),
+		Parameters: map[string][]string{
+			"Message": {"hello"},
+			"Other":   {"a", "b"},
+		},
+		ScheduleExpression: aws.String("rate(1 day)"),
+		Targets: []awstypes.Target{
+			{Key: aws.String("tag:Name"), Values: []string{"example", "other"}},
+			{Key: aws.String("tag:Environment"), Values: []string{"production"}},
+		},
+	}
+
+	testCases := map[string]struct {
+		update   func(*awstypes.AssociationVersionInfo)
+		expected []string
+	}{
+		"unchanged": {
+			update: func(v *awstypes.AssociationVersionInfo) {
+				v.AssociationVersion = aws.String("2")
+				v.CreatedDate = aws.Time(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC))
+			},
+		},
+		"schedule": {
+			update: func(v *awstypes.AssociationVersionInfo) {
+				v.ScheduleExpression = aws.String("rate(2 days)")
+			},
+			expected: []string{"schedule_expression"},
+		},
+		"parameters": {
+			update: func(v *awstypes.AssociationVersionInfo) {
+				v.Parameters = map[string][]string{
+					"Message": {"hello"},
+					"Other":   {"a"},
+					"New":     {"c"},
+				}
+			},
+			expected: []string{"parameters.New", "parameters.Other"},
+		},
+		"removed parameter": {
+			update: func(v *awstypes.AssociationVersionInfo) {
+				v.Parameters = map[string][]string{
+					"Message": {"hello"},
+				}
+			},
+			expected: []string{"parameters.Other"},
+		},
+		"reordered targets": {
+			update: func(v *awstypes.AssociationVersionInfo) {
+				v.Targets = []awstypes.Target{
+					{Key: aws.String("tag:Environment"), Values: []string{"production"}},
+					{Key: aws.String("tag:Name"), Values: []string{"other", "example"}},
+				}
+			},
+		},
+		"targets and document version": {
+			update: func(v *awstypes.AssociationVersionInfo) {
+				v.DocumentVersion = aws.String("2")
+				v.Targets = []awstypes.Target{
+					{Key: aws.String("tag:Name"), Values: []string{"other"}},
+				}
+			},
+			expected: []string{"document_version", "targets"},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			current := base
+			current.Parameters = maps.Clone(base.Parameters)
+			testCase.update(&current)
+
+			if got := associationVersionChanges(base, current); !slices.Equal(got, testCase.expected) {
+				t.Errorf("expected %v, got %v", testCase.expected, got)
+			}
+		})
+	}
+}
+
+func testAccAssociationVersionsDataSourceConfig_basic(rName, scheduleExpression, message string) string {
+	return fmt.Sprintf(`
+resource "automation_aws_ssm_document" "test" {
+  name          = %[1]q
+  document_type = "Command"
+
+  content = jsonencode({
+    schemaVersion = "2.2"
+    description   = "Echo a message."
+    parameters = {
+      Message = {
+        type    = "String"
+        default = "hello"
+      }
+    }
+    mainSteps = [{
+      action = "aws:runShellScript"
+      name   = "echo"
+      inputs = {
+        runCommand = ["echo {{ Message }}"]
+      }
+    }]
+  })
+}
+
+resource "automation_aws_ssm_association" "test" {
+  name                = automation_aws_ssm_document.test.name
+  schedule_expression = %[2]q
+
+  parameters = {
+    Message = [%[3]q]
+  }
+
+  targets = [{
+    key    = "tag:Name"
+    values = [%[1]q]
+  }]
+}
+
+data "automation_aws_ssm_association_versions" "test" {
+  association_id = automation_aws_ssm_association.test.association_id
+
+  depends_on = [automation_aws_ssm_association.test]
+}
+`, rName, scheduleExpression, message)
+}
@@ -0,0 +1,359 @@
+package provider
+
+import (
+	"context"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awstypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"strings"
+)
+
+var _ datasource.DataSource = &AWSSSMAssociationsDataSource{}
+var _ datasource.DataSourceWithConfigure = &AWSSSMAssociationsDataSource{}
+
+type AWSSSMAssociationsDataSource struct {
+	Meta Meta
+}
+
+type AWSSSMAssociationsDataSourceModel struct {
+	AssociationNamePrefix types.String             `tfsdk:"association_name_prefix"`
+	Associations          types.List               `tfsdk:"associations"`
+	DocumentName          types.String             `tfsdk:"document_name"`
+	Filters               []AssociationFilterModel `tfsdk:"filters"`
+	IncludeDetails        types.Bool               `tfsdk:"include_details"`
+	Status                types.String             `tfsdk:"status"`
+	Tags                  types.Map                `tfsdk:"tags"`
+}
+
+type AssociationFilterModel struct {
+	Key   types.String `tfsdk:"key"`
+	Value types.String `tfsdk:"value"`
+}
+
+type AssociationSummaryModel struct {
+	AssociationId          types.String `tfsdk:"association_id"`
+	AssociationName        types.String `tfsdk:"association_name"`
+	AssociationVersion     types.String `tfsdk:"association_version"`
+	ComplianceSeverity     types.String `tfsdk:"compliance_severity"`
+	DocumentVersion        types.String `tfsdk:"document_version"`
+	LastExecutionDate      types.String `tfsdk:"last_execution_date"`
+	Name                   types.String `tfsdk:"name"`
+	OverviewDetailedStatus types.String `tfsdk:"overview_detailed_status"`
+	OverviewStatus         types.String `tfsdk:"overview_status"`
+	ScheduleExpression     types.String `tfsdk:"schedule_expression"`
+	ScheduleOffset         types.Int32  `tfsdk:"schedule_offset"`
+	SyncCompliance         types.String `tfsdk:"sync_compliance"`
+	Tags                   types.Map    `tfsdk:"tags"`
+	Targets                TargetsValue `tfsdk:"targets"`
+}
+
+var associationSummaryObjectType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"association_id":           types.StringType,
+		"association_name":         types.StringType,
+		"association_version":      types.StringType,
+		"compliance_severity":      types.StringType,
+		"document_version":         types.StringType,
+		"last_execution_date":      types.StringType,
+		"name":                     types.StringType,
+		"overview_detailed_status": types.StringType,
+		"overview_status":          types.StringType,
+		"schedule_expression":      types.StringType,
+		"schedule_offset":          types.Int32Type,
+		"sync_compliance":          types.StringType,
+		"tags":                     types.MapType{ElemType: types.StringType},
+		"targets":                  NewTargetsType(),
+	},
+}
+
+func newAWSSSMAssociationsDataSource() datasource.DataSource {
+	return &AWSSSMAssociationsDataSource{}
+}
+
+func (a *AWSSSMAssociationsDataSource) Configure(_ context.Context, request datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if request.ProviderData == nil {
+		return
+	}
+
+	a.Meta = request.ProviderData.(Meta)
+}
+
+func (a *AWSSSMAssociationsDataSource) Metadata(_ context.Context, request datasource.MetadataRequest, response *datasource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_aws_ssm_associations"
+}
+
+func (a *AWSSSMAssociationsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, response *datasource.SchemaResponse) {
+	filterKeys := make([]string, 0, len(awstypes.AssociationFilterKey("").Values()))
+	for _, key := range awstypes.AssociationFilterKey("").Values() {
+		filterKeys = append(filterKeys, string(key))
+	}
+
+	response.Schema = schema.Schema{
+		MarkdownDescription: "Lists the SSM associations matching all of the given filters.  Tags and compliance settings are not returned by ListAssociations, they are read with two additional requests for every association when `include_details` is true.  Filtering by `tags` reads the tags of every association matching the other filters, narrow the list with `association_name_prefix`, `document_name`, `status` or `filters` in accounts with many associations.",
+		Attributes: map[string]schema.Attribute{
+			"association_name_prefix": schema.StringAttribute{
+				Description: "Only associations whose name starts with this prefix.",
+				Optional:    true,
+			},
+			"associations": schema.ListNestedAttribute{
+				Description: "The matching associations.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"association_id": schema.StringAttribute{
+							Description: "The ID of the association.",
+							Computed:    true,
+						},
+						"association_name": schema.StringAttribute{
+							Description: "The name of the association.",
+							Computed:    true,
+						},
+						"association_version": schema.StringAttribute{
+							Description: "The version of the association.",
+							Computed:    true,
+						},
+						"compliance_severity": schema.StringAttribute{
+							Description: "The severity of the association compliance.  Only read when include_details is true.",
+							Computed:    true,
+						},
+						"document_version": schema.StringAttribute{
+							Description: "The document version the association runs, a number, $DEFAULT or $LATEST.",
+							Computed:    true,
+						},
+						"last_execution_date": schema.StringAttribute{
+							Description: "The time the association last ran.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "The name of the SSM document.",
+							Computed:    true,
+						},
+						"overview_detailed_status": schema.StringAttribute{
+							Description: "The detailed status of the last run.",
+							Computed:    true,
+						},
+						"overview_status": schema.StringAttribute{
+							Description: "The status of the last run, Pending, Success or Failed.",
+							Computed:    true,
+						},
+						"schedule_expression": schema.StringAttribute{
+							Description: "The schedule of the association.",
+							Computed:    true,
+						},
+						"schedule_offset": schema.Int32Attribute{
+							Description: "The number of days to wait after the scheduled day to run the association.",
+							Computed:    true,
+						},
+						"sync_compliance": schema.StringAttribute{
+							Description: "The compliance mode of the association, AUTO or MANUAL.  Only read when include_details is true.",
+							Computed:    true,
+						},
+						"tags": schema.MapAttribute{
+							Description: "The tags of the association.  Only read when include_details is true or tags is set.",
+							Computed:    true,
+							ElementType: types.StringType,
+						},
+						"targets": schema.ListNestedAttribute{
+							Description: "The targets of the association.",
+							Computed:    true,
+							CustomType:  NewTargetsType(),
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"key": schema.StringAttribute{
+										Computed: true,
+									},
+									"values": schema.ListAttribute{
+										Computed:    true,
+										ElementType: types.StringType,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"document_name": schema.StringAttribute{
+				Description: "Only associations of this SSM document.",
+				Optional:    true,
+			},
+			"filters": schema.ListNestedAttribute{
+				Description: "Additional ListAssociations filters, e.g. InstanceId, LastExecutedAfter or ResourceGroupName.",
+				Optional:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"key": schema.StringAttribute{
+							Description: "The name of the filter.",
+							Required:    true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(filterKeys...),
+							},
+						},
+						"value": schema.StringAttribute{
+							Description: "The value of the filter.",
+							Required:    true,
+						},
+					},
+				},
+			},
+			"include_details": schema.BoolAttribute{
+				Description: "Whether to read the tags and compliance settings of every matching association.",
+				Optional:    true,
+			},
+			"status": schema.StringAttribute{
+				Description: "Only associations whose last run has this status, Pending, Success or Failed.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						string(awstypes.AssociationStatusNamePending),
+						string(awstypes.AssociationStatusNameSuccess),
+						string(awstypes.AssociationStatusNameFailed),
+					),
+				},
+			},
+			"tags": schema.MapAttribute{
+				MarkdownDescription: "Only associations with at least these tags.  Tags cannot be filtered by AWS, they are read for each association matching the other filters.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+		},
+	}
+}
+
+func (a *AWSSSMAssociationsDataSource) Read(ctx context.Context, request datasource.ReadRequest, response *datasource.ReadResponse) {
+	var data AWSSSMAssociationsDataSourceModel
+
+	response.Diagnostics.Append(request.Config.Get(ctx, &data)...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	conn := a.Meta.AWSClient.SSMClient
+
+	var filters []awstypes.AssociationFilter
+
+	if !data.DocumentName.IsNull() {
+		filters = append(filters, awstypes.AssociationFilter{
+			Key:   awstypes.AssociationFilterKeyName,
+			Value: data.DocumentName.ValueStringPointer(),
+		})
+	}
+
+	if !data.Status.IsNull() {
+		filters = append(filters, awstypes.AssociationFilter{
+			Key:   awstypes.AssociationFilterKeyStatus,
+			Value: data.Status.ValueStringPointer(),
+		})
+	}
+
+	for _, filter := range data.Filters {
+		filters = append(filters, awstypes.AssociationFilter{
+			Key:   awstypes.AssociationFilterKey(filter.Key.ValueString()),
+			Value: filter.Value.ValueStringPointer(),
+		})
+	}
+
+	prefix := data.AssociationNamePrefix.ValueString()
+
+	associations, err := findAssociations(ctx, conn, filters, func(association awstypes.Association) bool {
+		return strings.HasPrefix(aws.ToString(association.AssociationName), prefix)
+	})
+	if err != nil {
+		response.Diagnostics.AddError("Error listing associations", err.Error())
+		return
+	}
+
+	wantTags := make(map[string]string, len(data.Tags.Elements()))
+	for k, v := range data.Tags.Elements() {
+		wantTags[k] = v.(types.String).ValueString()
+	}
+
+	includeDetails := data.IncludeDetails.ValueBool()
+	readTags := includeDetails || len(wantTags) > 0
+
+	result := make([]AssociationSummaryModel, 0, len(associations))
+
+	for _, association := range associations {
+		id := aws.ToString(association.AssociationId)
+
+		var tags []awstypes.Tag
+
+		if readTags {
+			tags, err = findAssociationTagsByID(ctx, conn, id)
+			if err != nil {
+				response.Diagnostics.AddError("Error reading association tags", err.Error())
+				return
+			}
+
+			if !hasTags(tags, wantTags) {
+				continue
+			}
+		}
+
+		// ListAssociations leaves out the compliance settings, only describe the associations
+		// matching all filters.
+		var description *awstypes.AssociationDescription
+
+		if includeDetails {
+			description, err = FindAssociationByID(ctx, conn, id)
+			if err != nil {
+				response.Diagnostics.AddError("Error reading association", err.Error())
+				return
+			}
+		}
+
+		summary, diags := associationSummaryOut(ctx, association, description, tags, readTags)
+		response.Diagnostics.Append(diags...)
+
+		result = append(result, summary)
+	}
+
+	var diags diag.Diagnostics
+	data.Associations, diags = types.ListValueFrom(ctx, associationSummaryObjectType, result)
+	response.Diagnostics.Append(diags...)
+
+	response.Diagnostics.Append(response.State.Set(ctx, &data)...)
+}
+
+func associationSummaryOut(ctx context.Context, association awstypes.Association, description *awstypes.AssociationDescription, tags []awstypes.Tag, readTags bool) (AssociationSummaryModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	summary := AssociationSummaryModel{
+		AssociationId:      types.StringPointerValue(association.AssociationId),
+		AssociationName:    types.StringPointerValue(association.AssociationName),
+		AssociationVersion: types.StringPointerValue(association.AssociationVersion),
+		DocumentVersion:    types.StringPointerValue(association.DocumentVersion),
+		LastExecutionDate:  timeOut(association.LastExecutionDate),
+		Name:               types.StringPointerValue(association.Name),
+		ScheduleExpression: types.StringPointerValue(association.ScheduleExpression),
+		ScheduleOffset:     types.Int32PointerValue(association.ScheduleOffset),
+	}
+
+	if association.Overview != nil {
+		summary.OverviewDetailedStatus = types.StringPointerValue(association.Overview.DetailedStatus)
+		summary.OverviewStatus = types.StringPointerValue(association.Overview.Status)
+	}
+
+	if description != nil {
+		SetFrameworkFromString(&summary.ComplianceSeverity, string(description.ComplianceSeverity), true)
+		SetFrameworkFromString(&summary.SyncCompliance, string(description.SyncCompliance), true)
+	}
+
+	if readTags {
+		SetFrameworkTags(&summary.Tags, tags, true)
+	} else {
+		summary.Tags = types.MapNull(types.StringType)
+	}
+
+	targets, d := targetsOut(ctx, association.Targets)
+	diags.Append(d...)
+	summary.Targets = targets
+
+	return summary, diags
+}
@@ -0,0 +1,102 @@
+package provider
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"testing"
+)
+
+func TestAccSSMAssociationsDataSource_basic(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "automation_aws_ssm_association.test"
+	byDocumentName := "data.automation_aws_ssm_associations.by_document"
+	byPrefixName := "data.automation_aws_ssm_associations.by_prefix"
+	byTagName := "data.automation_aws_ssm_associations.by_tag"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAssociationsDataSourceConfig_basic(rName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(byDocumentName, "associations.#", "1"),
+					resource.TestCheckResourceAttrPair(byDocumentName, "associations.0.association_id", resourceName, "association_id"),
+					resource.TestCheckResourceAttr(byDocumentName, "associations.0.association_name", rName),
+					resource.TestCheckResourceAttr(byDocumentName, "associations.0.name", rName),
+					resource.TestCheckResourceAttr(byDocumentName, "associations.0.compliance_severity", "HIGH"),
+					resource.TestCheckResourceAttr(byDocumentName, "associations.0.schedule_expression", "rate(1 day)"),
+					resource.TestCheckResourceAttr(byDocumentName, "associations.0.targets.#", "1"),
+					resource.TestCheckResourceAttr(byDocumentName, "associations.0.targets.0.key", "tag:Name"),
+					resource.TestCheckResourceAttr(byDocumentName, "associations.0.tags.Name", rName),
+					resource.TestCheckResourceAttrSet(byDocumentName, "associations.0.overview_status"),
+					resource.TestCheckResourceAttr(byPrefixName, "associations.#", "1"),
+					resource.TestCheckResourceAttrPair(byPrefixName, "associations.0.association_id", resourceName, "association_id"),
+					resource.TestCheckNoResourceAttr(byPrefixName, "associations.0.compliance_severity"),
+					resource.TestCheckNoResourceAttr(byPrefixName, "associations.0.tags.%"),
+					resource.TestCheckResourceAttr(byTagName, "associations.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccAssociationsDataSourceConfig_basic(rName string) string {
+	return fmt.Sprintf(`
+resource "automation_aws_ssm_document" "test" {
+  name          = %[1]q
+  document_type = "Command"
+
+  content = jsonencode({
+    schemaVersion = "2.2"
+    description   = "Check ip configuration of a Linux instance."
+    mainSteps = [{
+      action = "aws:runShellScript"
+      name   = "runShellScript"
+      inputs = {
+        runCommand = ["ip addr"]
+      }
+    }]
+  })
+}
+
+resource "automation_aws_ssm_association" "test" {
+  name                = automation_aws_ssm_document.test.name
+  association_name    = %[1]q
+  compliance_severity = "HIGH"
+  schedule_expression = "rate(1 day)"
+
+  targets = [{
+    key    = "tag:Name"
+    values = [%[1]q]
+  }]
+
+  tags = {
+    Name = %[1]q
+  }
+}
+
+data "automation_aws_ssm_associations" "by_document" {
+  document_name   = automation_aws_ssm_association.test.name
+  include_details = true
+}
+
+data "automation_aws_ssm_associations" "by_prefix" {
+  association_name_prefix = %[1]q
+
+  filters = [{
+    key   = "AssociationName"
+    value = automation_aws_ssm_association.test.association_name
+  }]
+}
+
+data "automation_aws_ssm_associations" "by_tag" {
+  document_name = automation_aws_ssm_association.test.name
+
+  tags = {
+    Name = "%[1]s-other"
+  }
+}
+`, rName)
+}
@@ -0,0 +1,431 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	awstypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"maps"
+	"slices"
+	"time"
+)
+
+var _ datasource.DataSource = &AWSSSMAutomationExecutionDataSource{}
+var _ datasource.DataSourceWithConfigure = &AWSSSMAutomationExecutionDataSource{}
+
+type AWSSSMAutomationExecutionDataSource struct {
+	Meta Meta
+}
+
+type AWSSSMAutomationExecutionDataSourceModel struct {
+	AutomationExecutionId types.String    `tfsdk:"automation_execution_id"`
+	DocumentName          types.String    `tfsdk:"document_name"`
+	DocumentVersion       types.String    `tfsdk:"document_version"`
+	ExecutedBy            types.String    `tfsdk:"executed_by"`
+	ExecutionEndTime      types.String    `tfsdk:"execution_end_time"`
+	ExecutionStartTime    types.String    `tfsdk:"execution_start_time"`
+	FailureMessage        types.String    `tfsdk:"failure_message"`
+	Mode                  types.String    `tfsdk:"mode"`
+	Outputs               types.Map       `tfsdk:"outputs"`
+	Parameters            ParametersValue `tfsdk:"parameters"`
+	Status                types.String    `tfsdk:"status"`
+	StepExecutions        types.List      `tfsdk:"step_executions"`
+	Tags                  types.Map       `tfsdk:"tags"`
+	TargetParameterName   types.String    `tfsdk:"target_parameter_name"`
+	Targets               TargetsValue    `tfsdk:"targets"`
+}
+
+type StepExecutionModel struct {
+	Action             types.String `tfsdk:"action"`
+	ExecutionEndTime   types.String `tfsdk:"execution_end_time"`
+	ExecutionStartTime types.String `tfsdk:"execution_start_time"`
+	FailureMessage     types.String `tfsdk:"failure_message"`
+	Outputs            types.Map    `tfsdk:"outputs"`
+	Status             types.String `tfsdk:"status"`
+	StepName           types.String `tfsdk:"step_name"`
+}
+
+var stepExecutionObjectType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"action":               types.StringType,
+		"execution_end_time":   types.StringType,
+		"execution_start_time": types.StringType,
+		"failure_message":      types.StringType,
+		"outputs":              types.MapType{ElemType: types.ListType{ElemType: types.StringType}},
+		"status":               types.StringType,
+		"step_name":            types.StringType,
+	},
+}
+
+func newAWSSSMAutomationExecutionDataSource() datasource.DataSource {
+	return &AWSSSMAutomationExecutionDataSource{}
+}
+
+func (a *AWSSSMAutomationExecutionDataSource) Configure(_ context.Context, request datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if request.ProviderData == nil {
+		return
+	}
+
+	a.Meta = request.ProviderData.(Meta)
+}
+
+func (a *AWSSSMAutomationExecutionDataSource) Metadata(_ context.Context, request datasource.MetadataRequest, response *datasource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_aws_ssm_automation_execution"
+}
+
+func (a *AWSSSMAutomationExecutionDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, response *datasource.SchemaResponse) {
+	statuses := make([]string, 0, len(awstypes.AutomationExecutionStatus("").Values()))
+	for _, status := range awstypes.AutomationExecutionStatus("").Values() {
+		statuses = append(statuses, string(status))
+	}
+
+	response.Schema = schema.Schema{
+		MarkdownDescription: "Reads an Automation execution, including executions started outside Terraform.  Look up an execution by `automation_execution_id`, or the most recently started execution matching `document_name`, `status` and `tags`.",
+		Attributes: map[string]schema.Attribute{
+			"automation_execution_id": schema.StringAttribute{
+				Description: "The ID of the execution.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(
+						path.MatchRoot("document_name"),
+						path.MatchRoot("status"),
+						path.MatchRoot("tags"),
+					),
+					stringvalidator.AtLeastOneOf(
+						path.MatchRoot("document_name"),
+						path.MatchRoot("status"),
+						path.MatchRoot("tags"),
+					),
+				},
+			},
+			"document_name": schema.StringAttribute{
+				Description: "The name of the runbook the execution runs.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"document_version": schema.StringAttribute{
+				Description: "The version of the runbook the execution runs.",
+				Computed:    true,
+			},
+			"executed_by": schema.StringAttribute{
+				Description: "The ARN of the user who started the execution.",
+				Computed:    true,
+			},
+			"execution_end_time": schema.StringAttribute{
+				Description: "The time the execution finished.",
+				Computed:    true,
+			},
+			"execution_start_time": schema.StringAttribute{
+				Description: "The time the execution started.",
+				Computed:    true,
+			},
+			"failure_message": schema.StringAttribute{
+				Description: "The reason the execution failed.",
+				Computed:    true,
+			},
+			"mode": schema.StringAttribute{
+				Description: "The execution mode, Auto or Interactive.",
+				Computed:    true,
+			},
+			"outputs": schema.MapAttribute{
+				Description: "The outputs of the runbook.",
+				Computed:    true,
+				ElementType: types.ListType{ElemType: types.StringType},
+			},
+			"parameters": schema.MapAttribute{
+				Description: "The parameters the execution was started with.  Marked sensitive, as executions started outside Terraform may pass secrets as parameters.",
+				Computed:    true,
+				Sensitive:   true,
+				CustomType:  NewParametersType(),
+				ElementType: types.ListType{ElemType: types.StringType},
+			},
+			"status": schema.StringAttribute{
+				Description: "The status of the execution.  When looking up the latest execution, only executions with this status match.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(statuses...),
+				},
+			},
+			"step_executions": schema.ListNestedAttribute{
+				Description: "The steps of the execution.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"action": schema.StringAttribute{
+							Description: "The action of the step, e.g. aws:runCommand.",
+							Computed:    true,
+						},
+						"execution_end_time": schema.StringAttribute{
+							Description: "The time the step finished.",
+							Computed:    true,
+						},
+						"execution_start_time": schema.StringAttribute{
+							Description: "The time the step started.",
+							Computed:    true,
+						},
+						"failure_message": schema.StringAttribute{
+							Description: "The reason the step failed.",
+							Computed:    true,
+						},
+						"outputs": schema.MapAttribute{
+							Description: "The outputs of the step.",
+							Computed:    true,
+							ElementType: types.ListType{ElemType: types.StringType},
+						},
+						"status": schema.StringAttribute{
+							Description: "The status of the step.",
+							Computed:    true,
+						},
+						"step_name": schema.StringAttribute{
+							Description: "The name of the step.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"tags": schema.MapAttribute{
+				Description: "The tags of the execution.  When looking up the latest execution, only executions with at least these tags match.",
+				Optional:    true,
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"target_parameter_name": schema.StringAttribute{
+				Description: "The parameter of the runbook the targets are passed to.",
+				Computed:    true,
+			},
+			"targets": schema.ListNestedAttribute{
+				Description: "The targets of a rate control execution.",
+				Computed:    true,
+				CustomType:  NewTargetsType(),
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"key": schema.StringAttribute{
+							Computed: true,
+						},
+						"values": schema.ListAttribute{
+							Computed:    true,
+							ElementType: types.StringType,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (a *AWSSSMAutomationExecutionDataSource) Read(ctx context.Context, request datasource.ReadRequest, response *datasource.ReadResponse) {
+	var data AWSSSMAutomationExecutionDataSourceModel
+
+	response.Diagnostics.Append(request.Config.Get(ctx, &data)...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	conn := a.Meta.AWSClient.SSMClient
+	id := data.AutomationExecutionId.ValueString()
+
+	if id == "" {
+		var err error
+
+		id, err = findLatestAutomationExecutionID(ctx, conn, data.DocumentName.ValueString(), data.Status.ValueString(), data.Tags)
+		if err != nil {
+			response.Diagnostics.AddError("Error reading automation executions", err.Error())
+			return
+		}
+
+		if id == "" {
+			response.Diagnostics.AddError("No matching automation execution", "No automation execution matches the document name, status and tags.")
+			return
+		}
+	}
+
+	execution, err := FindAutomationExecutionById(ctx, conn, aws.String(id))
+	if err != nil {
+		response.Diagnostics.AddError("Error reading automation execution", err.Error())
+		return
+	}
+
+	if execution == nil {
+		response.Diagnostics.AddError("Automation execution not found", fmt.Sprintf("Automation execution %s does not exist.", id))
+		return
+	}
+
+	// Configured tags are a filter and are kept as configured.
+	if data.Tags.IsNull() {
+		tags, err := findTags(ctx, conn, awstypes.ResourceTypeForTaggingAutomation, id)
+		if err != nil {
+			response.Diagnostics.AddError("Error reading automation execution tags", err.Error())
+			return
+		}
+
+		SetFrameworkTags(&data.Tags, tags, true)
+	}
+
+	SetFrameworkFromStringPointer(&data.AutomationExecutionId, execution.AutomationExecutionId)
+	SetFrameworkFromStringPointer(&data.DocumentName, execution.DocumentName)
+	data.DocumentVersion = types.StringPointerValue(execution.DocumentVersion)
+	data.ExecutedBy = types.StringPointerValue(execution.ExecutedBy)
+	data.ExecutionEndTime = timeOut(execution.ExecutionEndTime)
+	data.ExecutionStartTime = timeOut(execution.ExecutionStartTime)
+	data.FailureMessage = types.StringPointerValue(execution.FailureMessage)
+	data.Mode = types.StringValue(string(execution.Mode))
+	data.Parameters = parametersOut(execution.Parameters)
+	data.Status = types.StringValue(string(execution.AutomationExecutionStatus))
+	data.TargetParameterName = types.StringPointerValue(execution.TargetParameterName)
+
+	var diags diag.Diagnostics
+	data.Outputs, diags = outputsOut(ctx, execution.Outputs)
+	response.Diagnostics.Append(diags...)
+	data.StepExecutions, diags = stepExecutionsOut(ctx, execution.StepExecutions)
+	response.Diagnostics.Append(diags...)
+	data.Targets, diags = targetsOut(ctx, execution.Targets)
+	response.Diagnostics.Append(diags...)
+
+	response.Diagnostics.Append(response.State.Set(ctx, &data)...)
+}
+
+// findLatestAutomationExecutionID returns the ID of the most recently started execution of
+// the document with the status and all the tags, or an empty ID when none match.
+func findLatestAutomationExecutionID(ctx context.Context, conn *ssm.Client, documentName, status string, tags types.Map) (string, error) {
+	var filters []awstypes.AutomationExecutionFilter
+
+	if documentName != "" {
+		filters = append(filters, awstypes.AutomationExecutionFilter{
+			Key:    awstypes.AutomationExecutionFilterKeyDocumentNamePrefix,
+			Values: []string{documentName},
+		})
+	}
+
+	if status != "" {
+		filters = append(filters, awstypes.AutomationExecutionFilter{
+			Key:    awstypes.AutomationExecutionFilterKeyExecutionStatus,
+			Values: []string{status},
+		})
+	}
+
+	wantTags := make(map[string]string, len(tags.Elements()))
+	for k, v := range tags.Elements() {
+		wantTags[k] = v.(types.String).ValueString()
+	}
+
+	// Tag values are not filtered by AWS, they are checked on the candidates.
+	if len(wantTags) > 0 {
+		filters = append(filters, awstypes.AutomationExecutionFilter{
+			Key:    awstypes.AutomationExecutionFilterKeyTagKey,
+			Values: slices.Sorted(maps.Keys(wantTags)),
+		})
+	}
+
+	var executions []awstypes.AutomationExecutionMetadata
+
+	pages := ssm.NewDescribeAutomationExecutionsPaginator(conn, &ssm.DescribeAutomationExecutionsInput{
+		Filters: filters,
+	})
+	for pages.HasMorePages() {
+		page, err := pages.NextPage(ctx)
+		if err != nil {
+			return "", err
+		}
+
+		for _, execution := range page.AutomationExecutionMetadataList {
+			// The document filter matches a name prefix.
+			if documentName == "" || aws.ToString(execution.DocumentName) == documentName {
+				executions = append(executions, execution)
+			}
+		}
+	}
+
+	sortAutomationExecutionsByStartTime(executions)
+
+	for _, execution := range executions {
+		if len(wantTags) == 0 {
+			return aws.ToString(execution.AutomationExecutionId), nil
+		}
+
+		tags, err := findTags(ctx, conn, awstypes.ResourceTypeForTaggingAutomation, aws.ToString(execution.AutomationExecutionId))
+		if err != nil {
+			return "", err
+		}
+
+		if hasTags(tags, wantTags) {
+			return aws.ToString(execution.AutomationExecutionId), nil
+		}
+	}
+
+	return "", nil
+}
+
+// sortAutomationExecutionsByStartTime sorts executions from the most recently started.
+func sortAutomationExecutionsByStartTime(executions []awstypes.AutomationExecutionMetadata) {
+	slices.SortStableFunc(executions, func(a, b awstypes.AutomationExecutionMetadata) int {
+		return aws.ToTime(b.ExecutionStartTime).Compare(aws.ToTime(a.ExecutionStartTime))
+	})
+}
+
+// hasTags reports whether tags contains every wanted tag.
+func hasTags(tags []awstypes.Tag, want map[string]string) bool {
+	found := 0
+
+	for _, tag := range tags {
+		if v, ok := want[aws.ToString(tag.Key)]; ok && v == aws.ToString(tag.Value) {
+			found++
+		}
+	}
+
+	return found == len(want)
+}
+
+func outputsOut(ctx context.Context, outputs map[string][]string) (types.Map, diag.Diagnostics) {
+	if outputs == nil {
+		outputs = map[string][]string{}
+	}
+
+	return types.MapValueFrom(ctx, types.ListType{ElemType: types.StringType}, outputs)
+}
+
+func stepExecutionsOut(ctx context.Context, steps []awstypes.StepExecution) (types.List, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	result := make([]StepExecutionModel, 0, len(steps))
+
+	for _, step := range steps {
+		outputs, d := outputsOut(ctx, step.Outputs)
+		diags.Append(d...)
+
+		result = append(result, StepExecutionModel{
+			Action:             types.StringPointerValue(step.Action),
+			ExecutionEndTime:   timeOut(step.ExecutionEndTime),
+			ExecutionStartTime: timeOut(step.ExecutionStartTime),
+			FailureMessage:     types.StringPointerValue(step.FailureMessage),
+			Outputs:            outputs,
+			Status:             types.StringValue(string(step.StepStatus)),
+			StepName:           types.StringPointerValue(step.StepName),
+		})
+	}
+
+	list, d := types.ListValueFrom(ctx, stepExecutionObjectType, result)
+	diags.Append(d...)
+
+	return list, diags
+}
+
+// timeOut formats a timestamp as RFC 3339, or null when it is not set.
+func timeOut(t *time.Time) types.String {
+	if t == nil {
+		return types.StringNull()
+	}
+
+	return types.StringValue(t.Format(time.RFC3339))
+}
@@ -0,0 +1,136 @@
+package provider
+
+import (
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awstypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestAccSSMAutomationExecutionDataSource_basic(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "automation_aws_ssm_start_automation_execution.test"
+	byIDName := "data.automation_aws_ssm_automation_execution.by_id"
+	latestName := "data.automation_aws_ssm_automation_execution.latest"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAutomationExecutionDataSourceConfig_basic(rName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair(byIDName, "automation_execution_id", resourceName, "automation_id"),
+					resource.TestCheckResourceAttr(byIDName, "document_name", rName),
+					resource.TestCheckResourceAttr(byIDName, "document_version", "1"),
+					resource.TestCheckResourceAttr(byIDName, "status", "Success"),
+					resource.TestCheckResourceAttr(byIDName, "tags.Name", rName),
+					resource.TestCheckResourceAttr(byIDName, "step_executions.#", "1"),
+					resource.TestCheckResourceAttr(byIDName, "step_executions.0.step_name", "Sleep"),
+					resource.TestCheckResourceAttr(byIDName, "step_executions.0.status", "Success"),
+					resource.TestCheckResourceAttrSet(byIDName, "execution_start_time"),
+					resource.TestCheckResourceAttrSet(byIDName, "execution_end_time"),
+					resource.TestCheckResourceAttrPair(latestName, "automation_execution_id", resourceName, "automation_id"),
+				),
+			},
+		},
+	})
+}
+
+func TestSortAutomationExecutionsByStartTime(t *testing.T) {
+	now := time.Now()
+
+	executions := []awstypes.AutomationExecutionMetadata{
+		{AutomationExecutionId: aws.String("old"), ExecutionStartTime: aws.Time(now.Add(-time.Hour))},
+		{AutomationExecutionId: aws.String("pending")},
+		{AutomationExecutionId: aws.String("new"), ExecutionStartTime: aws.Time(now)},
+	}
+
+	sortAutomationExecutionsByStartTime(executions)
+
+	var got []string
+	for _, execution := range executions {
+		got = append(got, aws.ToString(execution.AutomationExecutionId))
+	}
+
+	if want := []string{"new", "old", "pending"}; !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestHasTags(t *testing.T) {
+	tags := []awstypes.Tag{
+		{Key: aws.String("Name"), Value: aws.String("example")},
+		{Key: aws.String("Environment"), Value: aws.String("dev")},
+	}
+
+	testCases := map[string]struct {
+		want     map[string]string
+		expected bool
+	}{
+		"none":           {want: map[string]string{}, expected: true},
+		"subset":         {want: map[string]string{"Environment": "dev"}, expected: true},
+		"all":            {want: map[string]string{"Name": "example", "Environment": "dev"}, expected: true},
+		"different":      {want: map[string]string{"Environment": "prod"}},
+		"missing":        {want: map[string]string{"Owner": "team"}},
+		"partly missing": {want: map[string]string{"Name": "example", "Owner": "team"}},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := hasTags(tags, testCase.want); got != testCase.expected {
+				t.Errorf("expected %t, got %t", testCase.expected, got)
+			}
+		})
+	}
+}
+
+func testAccAutomationExecutionDataSourceConfig_basic(rName string) string {
+	return fmt.Sprintf(`
+resource "automation_aws_ssm_document" "test" {
+  name          = %[1]q
+  document_type = "Automation"
+
+  content = jsonencode({
+    schemaVersion = "0.3"
+    mainSteps = [{
+      name   = "Sleep"
+      action = "aws:sleep"
+      isEnd  = true
+      inputs = {
+        Duration = "PT5S"
+      }
+    }]
+  })
+}
+
+resource "automation_aws_ssm_start_automation_execution" "test" {
+  document_name = automation_aws_ssm_document.test.name
+
+  tags = {
+    Name = %[1]q
+  }
+
+  wait_for_success_timeout_seconds = 300
+}
+
+data "automation_aws_ssm_automation_execution" "by_id" {
+  automation_execution_id = automation_aws_ssm_start_automation_execution.test.automation_id
+}
+
+data "automation_aws_ssm_automation_execution" "latest" {
+  document_name = automation_aws_ssm_document.test.name
+  status        = "Success"
+
+  tags = {
+    Name = %[1]q
+  }
+
+  depends_on = [automation_aws_ssm_start_automation_execution.test]
+}
+`, rName)
+}
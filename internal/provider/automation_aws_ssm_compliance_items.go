@@ -0,0 +1,504 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/YakDriver/regexache"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	awstypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"slices"
+	"strings"
+	"time"
+)
+
+var _ resource.Resource = &AWSSSMComplianceItemsResource{}
+var _ resource.ResourceWithConfigure = &AWSSSMComplianceItemsResource{}
+var _ resource.ResourceWithImportState = &AWSSSMComplianceItemsResource{}
+
+type AWSSSMComplianceItemsResource struct {
+	Meta Meta
+}
+
+type AWSSSMComplianceItemsResourceModel struct {
+	ComplianceType types.String `tfsdk:"compliance_type"`
+	ExecutionId    types.String `tfsdk:"execution_id"`
+	ExecutionTime  types.String `tfsdk:"execution_time"`
+	ExecutionType  types.String `tfsdk:"execution_type"`
+	Items          types.List   `tfsdk:"items"`
+	ResourceId     types.String `tfsdk:"resource_id"`
+	ResourceType   types.String `tfsdk:"resource_type"`
+}
+
+type ComplianceItemEntryModel struct {
+	Details  types.Map    `tfsdk:"details"`
+	Id       types.String `tfsdk:"id"`
+	Severity types.String `tfsdk:"severity"`
+	Status   types.String `tfsdk:"status"`
+	Title    types.String `tfsdk:"title"`
+}
+
+var complianceItemEntryObjectType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"details":  types.MapType{ElemType: types.StringType},
+		"id":       types.StringType,
+		"severity": types.StringType,
+		"status":   types.StringType,
+		"title":    types.StringType,
+	},
+}
+
+func newAWSSSMComplianceItemsResource() resource.Resource {
+	return &AWSSSMComplianceItemsResource{}
+}
+
+func (a *AWSSSMComplianceItemsResource) Configure(_ context.Context, request resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if request.ProviderData == nil {
+		return
+	}
+
+	a.Meta = request.ProviderData.(Meta)
+}
+
+func (a *AWSSSMComplianceItemsResource) Metadata(_ context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_aws_ssm_compliance_items"
+}
+
+func (a *AWSSSMComplianceItemsResource) Schema(_ context.Context, _ resource.SchemaRequest, response *resource.SchemaResponse) {
+	severities := make([]string, 0, len(awstypes.ComplianceSeverity("").Values()))
+	for _, severity := range awstypes.ComplianceSeverity("").Values() {
+		severities = append(severities, string(severity))
+	}
+
+	response.Schema = schema.Schema{
+		MarkdownDescription: "Reports the compliance items of a compliance type for a resource, e.g. for associations with `sync_compliance` set to `MANUAL`.  The items replace all items of the compliance type reported for the resource and destroying the resource clears them.  For the `Association` compliance type only the items of the associations in `items` are replaced, the items of other associations on the resource are kept and destroying the resource leaves the items in place.  Items removed from `items` cannot be cleared either, they are reported as `COMPLIANT`.",
+		Attributes: map[string]schema.Attribute{
+			"compliance_type": schema.StringAttribute{
+				MarkdownDescription: "The compliance type, e.g. `Association`, `Patch` or `Custom:<name>`.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 100),
+					stringvalidator.RegexMatches(regexache.MustCompile(`^([A-Za-z0-9_-]\w+|Custom:[A-Za-z0-9_-]\w+)$`), "must be a compliance type or Custom:<name>"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"execution_id": schema.StringAttribute{
+				Description: "The ID of the execution reporting the items, e.g. an association or automation execution ID.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 100),
+				},
+			},
+			"execution_time": schema.StringAttribute{
+				Description: "The time the items were last reported.",
+				Computed:    true,
+			},
+			"execution_type": schema.StringAttribute{
+				Description: "The type of the execution reporting the items, e.g. Command or Automation.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 50),
+				},
+			},
+			"items": schema.ListNestedAttribute{
+				Description: "The compliance items of the resource.",
+				Required:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"details": schema.MapAttribute{
+							Description: "Additional information about the compliance item.",
+							Optional:    true,
+							ElementType: types.StringType,
+						},
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the compliance item, e.g. the association ID for the `Association` compliance type.",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.LengthBetween(1, 100),
+							},
+						},
+						"severity": schema.StringAttribute{
+							Description: "The severity of the compliance item, CRITICAL, HIGH, MEDIUM, LOW, INFORMATIONAL or UNSPECIFIED.",
+							Required:    true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(severities...),
+							},
+						},
+						"status": schema.StringAttribute{
+							Description: "The status of the compliance item, COMPLIANT or NON_COMPLIANT.",
+							Required:    true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(
+									string(awstypes.ComplianceStatusCompliant),
+									string(awstypes.ComplianceStatusNonCompliant),
+								),
+							},
+						},
+						"title": schema.StringAttribute{
+							Description: "The title of the compliance item.",
+							Optional:    true,
+							Validators: []validator.String{
+								stringvalidator.LengthBetween(1, 500),
+							},
+						},
+					},
+				},
+				Validators: []validator.List{
+					listvalidator.SizeBetween(1, 10000),
+				},
+			},
+			"resource_id": schema.StringAttribute{
+				Description: "The ID of the resource, e.g. a managed instance ID.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 100),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"resource_type": schema.StringAttribute{
+				Description: "The type of the resource, ManagedInstance.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(complianceResourceTypeManagedInstance),
+				Validators: []validator.String{
+					stringvalidator.OneOf(complianceResourceTypeManagedInstance),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (a *AWSSSMComplianceItemsResource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
+	var data AWSSSMComplianceItemsResourceModel
+
+	response.Diagnostics.Append(request.Plan.Get(ctx, &data)...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	response.Diagnostics.Append(putComplianceItems(ctx, a.Meta.AWSClient.SSMClient, &data, nil)...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	response.Diagnostics.Append(response.State.Set(ctx, &data)...)
+}
+
+func (a *AWSSSMComplianceItemsResource) Read(ctx context.Context, request resource.ReadRequest, response *resource.ReadResponse) {
+	var data AWSSSMComplianceItemsResourceModel
+
+	response.Diagnostics.Append(request.State.Get(ctx, &data)...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	items, err := findComplianceItems(ctx, a.Meta.AWSClient.SSMClient, data.ResourceId.ValueString(), data.ResourceType.ValueString(), data.ComplianceType.ValueString())
+	if err != nil {
+		response.Diagnostics.AddError("Error reading compliance items", err.Error())
+		return
+	}
+
+	var state []ComplianceItemEntryModel
+	if !data.Items.IsNull() {
+		response.Diagnostics.Append(data.Items.ElementsAs(ctx, &state, false)...)
+	}
+
+	// Partial uploads share the compliance type with other reporters, only the items in
+	// state belong to this resource.
+	if complianceUploadType(data.ComplianceType.ValueString()) == awstypes.ComplianceUploadTypePartial && len(state) > 0 {
+		items = slices.DeleteFunc(items, func(item awstypes.ComplianceItem) bool {
+			return !slices.ContainsFunc(state, func(entry ComplianceItemEntryModel) bool {
+				return entry.Id.ValueString() == aws.ToString(item.Id)
+			})
+		})
+	}
+
+	if len(items) == 0 {
+		response.State.RemoveResource(ctx)
+		return
+	}
+
+	var diags diag.Diagnostics
+	data.Items, diags = complianceItemEntriesOut(ctx, items, state)
+	response.Diagnostics.Append(diags...)
+
+	if summary := items[0].ExecutionSummary; summary != nil {
+		data.ExecutionId = complianceStringOut(summary.ExecutionId)
+		data.ExecutionType = complianceStringOut(summary.ExecutionType)
+		data.ExecutionTime = timeOut(summary.ExecutionTime)
+	}
+
+	response.Diagnostics.Append(response.State.Set(ctx, &data)...)
+}
+
+func (a *AWSSSMComplianceItemsResource) Update(ctx context.Context, request resource.UpdateRequest, response *resource.UpdateResponse) {
+	var plan, state AWSSSMComplianceItemsResourceModel
+
+	response.Diagnostics.Append(request.Plan.Get(ctx, &plan)...)
+	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	// Items of a partial upload can only be replaced, report the removed items as compliant
+	// so that they do not stay non-compliant unnoticed, as Read only shows the items in state.
+	var removed []ComplianceItemEntryModel
+
+	if complianceUploadType(plan.ComplianceType.ValueString()) == awstypes.ComplianceUploadTypePartial {
+		var planEntries, stateEntries []ComplianceItemEntryModel
+
+		response.Diagnostics.Append(plan.Items.ElementsAs(ctx, &planEntries, false)...)
+		response.Diagnostics.Append(state.Items.ElementsAs(ctx, &stateEntries, false)...)
+
+		if response.Diagnostics.HasError() {
+			return
+		}
+
+		removed = removedComplianceItems(stateEntries, planEntries)
+	}
+
+	response.Diagnostics.Append(putComplianceItems(ctx, a.Meta.AWSClient.SSMClient, &plan, removed)...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	if len(removed) > 0 {
+		ids := make([]string, 0, len(removed))
+		for _, entry := range removed {
+			ids = append(ids, entry.Id.ValueString())
+		}
+
+		response.Diagnostics.AddWarning(
+			"Compliance items reported compliant",
+			fmt.Sprintf("The %s compliance items %s of %s cannot be removed, they are reported as COMPLIANT instead.", plan.ComplianceType.ValueString(), strings.Join(ids, ", "), plan.ResourceId.ValueString()),
+		)
+	}
+
+	response.Diagnostics.Append(response.State.Set(ctx, &plan)...)
+}
+
+func (a *AWSSSMComplianceItemsResource) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse) {
+	var data AWSSSMComplianceItemsResourceModel
+
+	response.Diagnostics.Append(request.State.Get(ctx, &data)...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	// Items of a partial upload can only be replaced, not removed.
+	if complianceUploadType(data.ComplianceType.ValueString()) == awstypes.ComplianceUploadTypePartial {
+		response.Diagnostics.AddWarning(
+			"Compliance items not cleared",
+			fmt.Sprintf("The %s compliance items of %s are kept, as clearing them would also clear the items of other associations.  They are replaced by the next report for the association.", data.ComplianceType.ValueString(), data.ResourceId.ValueString()),
+		)
+		return
+	}
+
+	// A complete upload without items clears the compliance type from the resource.
+	_, err := a.Meta.AWSClient.SSMClient.PutComplianceItems(ctx, &ssm.PutComplianceItemsInput{
+		ComplianceType: data.ComplianceType.ValueStringPointer(),
+		ExecutionSummary: &awstypes.ComplianceExecutionSummary{
+			ExecutionTime: aws.Time(time.Now()),
+		},
+		Items:        []awstypes.ComplianceItemEntry{},
+		ResourceId:   data.ResourceId.ValueStringPointer(),
+		ResourceType: data.ResourceType.ValueStringPointer(),
+		UploadType:   awstypes.ComplianceUploadTypeComplete,
+	})
+	if err != nil {
+		response.Diagnostics.AddError("Error clearing compliance items", err.Error())
+		return
+	}
+}
+
+func (a *AWSSSMComplianceItemsResource) ImportState(ctx context.Context, request resource.ImportStateRequest, response *resource.ImportStateResponse) {
+	resourceId, complianceType, err := parseComplianceItemsImportID(request.ID)
+	if err != nil {
+		response.Diagnostics.AddError("Error importing compliance items", err.Error())
+		return
+	}
+
+	response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("compliance_type"), complianceType)...)
+	response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("resource_id"), resourceId)...)
+	response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("resource_type"), complianceResourceTypeManagedInstance)...)
+}
+
+// complianceUploadType returns how an upload replaces the items of the compliance type.
+// Association items of all associations on the resource share the Association compliance
+// type, a partial upload only replaces those of the associations uploaded.
+func complianceUploadType(complianceType string) awstypes.ComplianceUploadType {
+	if complianceType == complianceTypeAssociation {
+		return awstypes.ComplianceUploadTypePartial
+	}
+
+	return awstypes.ComplianceUploadTypeComplete
+}
+
+// parseComplianceItemsImportID splits an import ID of the form <resource_id>/<compliance_type>.
+func parseComplianceItemsImportID(importID string) (string, string, error) {
+	resourceId, complianceType, ok := strings.Cut(importID, "/")
+	if !ok || resourceId == "" || complianceType == "" {
+		return "", "", fmt.Errorf("unexpected format for import ID (%s), expected <resource_id>/<compliance_type>", importID)
+	}
+
+	return resourceId, complianceType, nil
+}
+
+// putComplianceItems uploads the items of data, and the removed items of a partial upload
+// as compliant.
+func putComplianceItems(ctx context.Context, conn *ssm.Client, data *AWSSSMComplianceItemsResourceModel, removed []ComplianceItemEntryModel) diag.Diagnostics {
+	var entries []ComplianceItemEntryModel
+	diags := data.Items.ElementsAs(ctx, &entries, false)
+
+	if diags.HasError() {
+		return diags
+	}
+
+	executionTime := time.Now().UTC()
+
+	input := &ssm.PutComplianceItemsInput{
+		ComplianceType: data.ComplianceType.ValueStringPointer(),
+		ExecutionSummary: &awstypes.ComplianceExecutionSummary{
+			ExecutionId:   data.ExecutionId.ValueStringPointer(),
+			ExecutionTime: aws.Time(executionTime),
+			ExecutionType: data.ExecutionType.ValueStringPointer(),
+		},
+		Items:        make([]awstypes.ComplianceItemEntry, 0, len(entries)+len(removed)),
+		ResourceId:   data.ResourceId.ValueStringPointer(),
+		ResourceType: data.ResourceType.ValueStringPointer(),
+		UploadType:   complianceUploadType(data.ComplianceType.ValueString()),
+	}
+
+	for _, entry := range entries {
+		item := awstypes.ComplianceItemEntry{
+			Id:       entry.Id.ValueStringPointer(),
+			Severity: awstypes.ComplianceSeverity(entry.Severity.ValueString()),
+			Status:   awstypes.ComplianceStatus(entry.Status.ValueString()),
+			Title:    entry.Title.ValueStringPointer(),
+		}
+
+		if !entry.Details.IsNull() {
+			diags.Append(entry.Details.ElementsAs(ctx, &item.Details, false)...)
+		}
+
+		input.Items = append(input.Items, item)
+	}
+
+	for _, entry := range removed {
+		input.Items = append(input.Items, awstypes.ComplianceItemEntry{
+			Id:       entry.Id.ValueStringPointer(),
+			Severity: awstypes.ComplianceSeverity(entry.Severity.ValueString()),
+			Status:   awstypes.ComplianceStatusCompliant,
+			Title:    entry.Title.ValueStringPointer(),
+		})
+	}
+
+	if diags.HasError() {
+		return diags
+	}
+
+	if _, err := conn.PutComplianceItems(ctx, input); err != nil {
+		diags.AddError("Error putting compliance items", err.Error())
+		return diags
+	}
+
+	data.ExecutionTime = timeOut(&executionTime)
+
+	return diags
+}
+
+// removedComplianceItems returns the items in state whose ID is not in the plan.
+func removedComplianceItems(state, plan []ComplianceItemEntryModel) []ComplianceItemEntryModel {
+	var removed []ComplianceItemEntryModel
+
+	for _, entry := range state {
+		if !slices.ContainsFunc(plan, func(planned ComplianceItemEntryModel) bool {
+			return planned.Id.Equal(entry.Id)
+		}) {
+			removed = append(removed, entry)
+		}
+	}
+
+	return removed
+}
+
+// complianceItemEntriesOut converts the reported items, keeping the items in the order of
+// the state so that AWS returning them in another order does not show as drift.  Items
+// not in state follow in the order returned.
+func complianceItemEntriesOut(ctx context.Context, items []awstypes.ComplianceItem, state []ComplianceItemEntryModel) (types.List, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	ordered := slices.Clone(items)
+	position := func(item awstypes.ComplianceItem) int {
+		index := slices.IndexFunc(state, func(entry ComplianceItemEntryModel) bool {
+			return entry.Id.ValueString() == aws.ToString(item.Id)
+		})
+		if index < 0 {
+			return len(state)
+		}
+
+		return index
+	}
+	slices.SortStableFunc(ordered, func(a, b awstypes.ComplianceItem) int {
+		return position(a) - position(b)
+	})
+
+	result := make([]ComplianceItemEntryModel, 0, len(ordered))
+
+	for _, item := range ordered {
+		entry := ComplianceItemEntryModel{
+			Details:  types.MapNull(types.StringType),
+			Id:       types.StringPointerValue(item.Id),
+			Severity: types.StringValue(string(item.Severity)),
+			Status:   types.StringValue(string(item.Status)),
+			Title:    complianceStringOut(item.Title),
+		}
+
+		if len(item.Details) > 0 {
+			var d diag.Diagnostics
+			entry.Details, d = types.MapValueFrom(ctx, types.StringType, item.Details)
+			diags.Append(d...)
+		}
+
+		result = append(result, entry)
+	}
+
+	list, d := types.ListValueFrom(ctx, complianceItemEntryObjectType, result)
+	diags.Append(d...)
+
+	return list, diags
+}
+
+// complianceStringOut converts an optional string, AWS reports unset values as empty strings.
+func complianceStringOut(value *string) types.String {
+	if aws.ToString(value) == "" {
+		return types.StringNull()
+	}
+
+	return types.StringValue(aws.ToString(value))
+}
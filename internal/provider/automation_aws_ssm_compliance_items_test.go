@@ -0,0 +1,312 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	awstypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"slices"
+	"testing"
+)
+
+func TestAccSSMComplianceItems_basic(t *testing.T) {
+	ctx := context.Background()
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "automation_aws_ssm_compliance_items.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		ExternalProviders: map[string]resource.ExternalProvider{
+			"aws": {
+				Source:            "hashicorp/aws",
+				VersionConstraint: "5.87.0",
+			},
+			"time": {
+				Source:            "hashicorp/time",
+				VersionConstraint: "0.12.1",
+			},
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckComplianceItemsDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccComplianceItemsConfig_basic(rName, "COMPLIANT"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckComplianceItemsExist(ctx, resourceName),
+					resource.TestCheckResourceAttr(resourceName, "compliance_type", "Custom:TerraformAcceptance"),
+					resource.TestCheckResourceAttr(resourceName, "resource_type", "ManagedInstance"),
+					resource.TestCheckResourceAttr(resourceName, "items.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "items.0.status", "COMPLIANT"),
+					resource.TestCheckResourceAttr(resourceName, "items.0.details.Owner", rName),
+					resource.TestCheckResourceAttrSet(resourceName, "execution_time"),
+				),
+			},
+			{
+				Config: testAccComplianceItemsConfig_basic(rName, "NON_COMPLIANT"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckComplianceItemsExist(ctx, resourceName),
+					resource.TestCheckResourceAttr(resourceName, "items.0.status", "NON_COMPLIANT"),
+				),
+			},
+			{
+				ResourceName:                         resourceName,
+				ImportState:                          true,
+				ImportStateIdFunc:                    testAccComplianceItemsImportStateIdFunc(resourceName),
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "resource_id",
+			},
+		},
+	})
+}
+
+func TestParseComplianceItemsImportID(t *testing.T) {
+	testCases := map[string]struct {
+		importID       string
+		resourceId     string
+		complianceType string
+		err            bool
+	}{
+		"association":     {importID: "i-0123456789abcdef0/Association", resourceId: "i-0123456789abcdef0", complianceType: "Association"},
+		"custom":          {importID: "mi-0123456789abcdef0/Custom:Backup", resourceId: "mi-0123456789abcdef0", complianceType: "Custom:Backup"},
+		"no separator":    {importID: "i-0123456789abcdef0", err: true},
+		"no type":         {importID: "i-0123456789abcdef0/", err: true},
+		"no resource id":  {importID: "/Association", err: true},
+		"empty import id": {importID: "", err: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resourceId, complianceType, err := parseComplianceItemsImportID(testCase.importID)
+
+			if testCase.err {
+				if err == nil {
+					t.Error("expected error")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if resourceId != testCase.resourceId || complianceType != testCase.complianceType {
+				t.Errorf("expected %q %q, got %q %q", testCase.resourceId, testCase.complianceType, resourceId, complianceType)
+			}
+		})
+	}
+}
+
+func TestComplianceUploadType(t *testing.T) {
+	testCases := map[string]struct {
+		complianceType string
+		want           awstypes.ComplianceUploadType
+	}{
+		"association": {complianceType: "Association", want: awstypes.ComplianceUploadTypePartial},
+		"patch":       {complianceType: "Patch", want: awstypes.ComplianceUploadTypeComplete},
+		"custom":      {complianceType: "Custom:Backup", want: awstypes.ComplianceUploadTypeComplete},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := complianceUploadType(testCase.complianceType); got != testCase.want {
+				t.Errorf("expected %s, got %s", testCase.want, got)
+			}
+		})
+	}
+}
+
+func TestRemovedComplianceItems(t *testing.T) {
+	state := []ComplianceItemEntryModel{
+		{Id: types.StringValue("a")},
+		{Id: types.StringValue("b")},
+		{Id: types.StringValue("c")},
+	}
+
+	plan := []ComplianceItemEntryModel{
+		{Id: types.StringValue("b")},
+		{Id: types.StringValue("d")},
+	}
+
+	var ids []string
+	for _, entry := range removedComplianceItems(state, plan) {
+		ids = append(ids, entry.Id.ValueString())
+	}
+
+	if want := []string{"a", "c"}; !slices.Equal(ids, want) {
+		t.Errorf("expected removed %v, got %v", want, ids)
+	}
+
+	if removed := removedComplianceItems(state, state); len(removed) != 0 {
+		t.Errorf("expected no removed items, got %v", removed)
+	}
+}
+
+func TestComplianceItemEntriesOut(t *testing.T) {
+	ctx := context.Background()
+
+	items := []awstypes.ComplianceItem{
+		{Id: aws.String("c"), Severity: awstypes.ComplianceSeverityLow, Status: awstypes.ComplianceStatusCompliant},
+		{Id: aws.String("a"), Severity: awstypes.ComplianceSeverityHigh, Status: awstypes.ComplianceStatusNonCompliant, Title: aws.String("")},
+		{Id: aws.String("b"), Severity: awstypes.ComplianceSeverityMedium, Status: awstypes.ComplianceStatusCompliant, Details: map[string]string{"Owner": "ops"}},
+	}
+
+	state := []ComplianceItemEntryModel{
+		{Id: types.StringValue("b")},
+		{Id: types.StringValue("a")},
+	}
+
+	list, diags := complianceItemEntriesOut(ctx, items, state)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	var got []ComplianceItemEntryModel
+	if diags := list.ElementsAs(ctx, &got, false); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	var ids []string
+	for _, entry := range got {
+		ids = append(ids, entry.Id.ValueString())
+	}
+
+	if want := []string{"b", "a", "c"}; !slices.Equal(ids, want) {
+		t.Errorf("expected order %v, got %v", want, ids)
+	}
+
+	if !got[1].Title.IsNull() {
+		t.Errorf("expected empty title to be null, got %s", got[1].Title)
+	}
+
+	if !got[1].Details.IsNull() || got[0].Details.IsNull() {
+		t.Errorf("unexpected details %s %s", got[0].Details, got[1].Details)
+	}
+}
+
+func testAccComplianceItemsConfig_basic(rName, status string) string {
+	return ConfigCompose(configLatestAmazonLinux2HVMEBSAMI(ec2types.ArchitectureValuesX8664), fmt.Sprintf(`
+data "aws_partition" "current" {}
+
+resource "aws_iam_role" "test" {
+  name = %[1]q
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Action    = "sts:AssumeRole"
+      Effect    = "Allow"
+      Principal = { Service = "ec2.${data.aws_partition.current.dns_suffix}" }
+    }]
+  })
+}
+
+resource "aws_iam_role_policy_attachment" "test" {
+  role       = aws_iam_role.test.name
+  policy_arn = "arn:${data.aws_partition.current.partition}:iam::aws:policy/AmazonSSMManagedInstanceCore"
+}
+
+resource "aws_iam_instance_profile" "test" {
+  name = %[1]q
+  role = aws_iam_role.test.name
+}
+
+resource "aws_instance" "test" {
+  ami                  = data.aws_ami.amzn2-ami-minimal-hvm-ebs-x86_64.id
+  instance_type        = "t3.micro"
+  iam_instance_profile = aws_iam_instance_profile.test.name
+
+  tags = {
+    Name = %[1]q
+  }
+
+  depends_on = [aws_iam_role_policy_attachment.test]
+}
+
+# Give the SSM Agent time to register the instance.
+resource "time_sleep" "test" {
+  create_duration = "120s"
+
+  depends_on = [aws_instance.test]
+}
+
+resource "automation_aws_ssm_compliance_items" "test" {
+  resource_id     = aws_instance.test.id
+  compliance_type = "Custom:TerraformAcceptance"
+  execution_type  = "Terraform"
+
+  items = [{
+    id       = "check-1"
+    title    = %[1]q
+    severity = "HIGH"
+    status   = %[2]q
+
+    details = {
+      Owner = %[1]q
+    }
+  }]
+
+  depends_on = [time_sleep.test]
+}
+`, rName, status))
+}
+
+func testAccComplianceItemsImportStateIdFunc(n string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return "", fmt.Errorf("not found: %s", n)
+		}
+
+		return rs.Primary.Attributes["resource_id"] + "/" + rs.Primary.Attributes["compliance_type"], nil
+	}
+}
+
+func testAccCheckComplianceItemsExist(ctx context.Context, n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		conn := getProviderMeta(ctx).AWSClient.SSMClient
+
+		items, err := findComplianceItems(ctx, conn, rs.Primary.Attributes["resource_id"], rs.Primary.Attributes["resource_type"], rs.Primary.Attributes["compliance_type"])
+		if err != nil {
+			return err
+		}
+
+		if len(items) == 0 {
+			return fmt.Errorf("SSM compliance items of %s not found", rs.Primary.Attributes["resource_id"])
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckComplianceItemsDestroy(ctx context.Context) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := getProviderMeta(ctx).AWSClient.SSMClient
+
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "automation_aws_ssm_compliance_items" {
+				continue
+			}
+
+			items, err := findComplianceItems(ctx, conn, rs.Primary.Attributes["resource_id"], rs.Primary.Attributes["resource_type"], rs.Primary.Attributes["compliance_type"])
+			if err != nil {
+				return err
+			}
+
+			if len(items) != 0 {
+				return fmt.Errorf("SSM compliance items of %s still exist", rs.Primary.Attributes["resource_id"])
+			}
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,781 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/YakDriver/regexache"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	awstypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/coding-ia/terraform-provider-automation/internal/framework/errs"
+	"github.com/coding-ia/terraform-provider-automation/internal/ssmdoc"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"time"
+)
+
+var _ resource.Resource = &AWSSSMDocumentResource{}
+var _ resource.ResourceWithConfigure = &AWSSSMDocumentResource{}
+var _ resource.ResourceWithValidateConfig = &AWSSSMDocumentResource{}
+var _ resource.ResourceWithModifyPlan = &AWSSSMDocumentResource{}
+var _ resource.ResourceWithImportState = &AWSSSMDocumentResource{}
+
+const (
+	documentActiveTimeout  = 5 * time.Minute
+	documentDeletedTimeout = 5 * time.Minute
+)
+
+type AWSSSMDocumentResource struct {
+	Meta Meta
+}
+
+type AWSSSMDocumentResourceModel struct {
+	Arn               types.String         `tfsdk:"arn"`
+	Attachments       types.List           `tfsdk:"attachments"`
+	Content           DocumentContentValue `tfsdk:"content"`
+	CreatedDate       types.String         `tfsdk:"created_date"`
+	DefaultVersion    types.String         `tfsdk:"default_version"`
+	DocumentFormat    types.String         `tfsdk:"document_format"`
+	DocumentType      types.String         `tfsdk:"document_type"`
+	DocumentVersion   types.String         `tfsdk:"document_version"`
+	Name              types.String         `tfsdk:"name"`
+	Requires          types.List           `tfsdk:"requires"`
+	SetDefaultVersion types.Bool           `tfsdk:"set_default_version"`
+	Status            types.String         `tfsdk:"status"`
+	Tags              types.Map            `tfsdk:"tags"`
+	TagsAll           types.Map            `tfsdk:"tags_all"`
+	TargetType        types.String         `tfsdk:"target_type"`
+	VersionName       types.String         `tfsdk:"version_name"`
+}
+
+type DocumentAttachmentModel struct {
+	Key    types.String `tfsdk:"key"`
+	Name   types.String `tfsdk:"name"`
+	Values types.List   `tfsdk:"values"`
+}
+
+type DocumentRequiresModel struct {
+	Name    types.String `tfsdk:"name"`
+	Version types.String `tfsdk:"version"`
+}
+
+var documentRequiresObjectType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"name":    types.StringType,
+		"version": types.StringType,
+	},
+}
+
+func newAWSSSMDocumentResource() resource.Resource {
+	return &AWSSSMDocumentResource{}
+}
+
+func (a *AWSSSMDocumentResource) Configure(_ context.Context, request resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if request.ProviderData == nil {
+		return
+	}
+
+	a.Meta = request.ProviderData.(Meta)
+}
+
+func (a *AWSSSMDocumentResource) Metadata(_ context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_aws_ssm_document"
+}
+
+func (a *AWSSSMDocumentResource) Schema(_ context.Context, _ resource.SchemaRequest, response *resource.SchemaResponse) {
+	documentTypes := make([]string, 0, len(awstypes.DocumentType("").Values()))
+	for _, documentType := range awstypes.DocumentType("").Values() {
+		documentTypes = append(documentTypes, string(documentType))
+	}
+
+	response.Schema = schema.Schema{
+		MarkdownDescription: "Manages an SSM Document.  Changing the content creates a new document version, which becomes the default version unless `set_default_version` is false.",
+		Attributes: map[string]schema.Attribute{
+			"arn": schema.StringAttribute{
+				Description: "The ARN of the SSM Document.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"attachments": schema.ListNestedAttribute{
+				MarkdownDescription: "The files attached to the document, such as the packages of a Distributor package.  Attachments are not refreshed from AWS.",
+				Optional:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"key": schema.StringAttribute{
+							Description: "The key of the attachment source, SourceUrl, S3FileUrl or AttachmentReference.",
+							Required:    true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(
+									string(awstypes.AttachmentsSourceKeySourceUrl),
+									string(awstypes.AttachmentsSourceKeyS3FileUrl),
+									string(awstypes.AttachmentsSourceKeyAttachmentReference),
+								),
+							},
+						},
+						"name": schema.StringAttribute{
+							Description: "The name of the attachment.",
+							Optional:    true,
+						},
+						"values": schema.ListAttribute{
+							Description: "The URL or reference of the attachment source.",
+							Required:    true,
+							ElementType: types.StringType,
+							Validators: []validator.List{
+								listvalidator.SizeBetween(1, 1),
+							},
+						},
+					},
+				},
+				Validators: []validator.List{
+					listvalidator.SizeAtMost(20),
+				},
+			},
+			"content": schema.StringAttribute{
+				MarkdownDescription: "The content of the document in the format set by `document_format`.  JSON and YAML content is parsed and validated against the schema of the document type when planning.",
+				Required:            true,
+				CustomType:          NewDocumentContentType(),
+			},
+			"created_date": schema.StringAttribute{
+				Description: "The date the document was created.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"default_version": schema.StringAttribute{
+				Description: "The default version of the document.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"document_format": schema.StringAttribute{
+				Description: "The format of the content, JSON, YAML or TEXT.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(string(awstypes.DocumentFormatJson)),
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						string(awstypes.DocumentFormatJson),
+						string(awstypes.DocumentFormatYaml),
+						string(awstypes.DocumentFormatText),
+					),
+				},
+			},
+			"document_type": schema.StringAttribute{
+				Description: "The type of document, e.g. Command, Automation, Session or Package.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(documentTypes...),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"document_version": schema.StringAttribute{
+				Description: "The version of the document holding the content, the latest version.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the document.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexache.MustCompile(`^[0-9A-Za-z_.-]{3,128}$`), "must contain only alphanumeric, underscore, hyphen, or period characters"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"requires": schema.ListNestedAttribute{
+				Description: "The documents this document requires, e.g. the ApplicationConfigurationSchema document of an ApplicationConfiguration document.",
+				Optional:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description: "The name of the required document.",
+							Required:    true,
+						},
+						"version": schema.StringAttribute{
+							Description: "The version of the required document.",
+							Optional:    true,
+						},
+					},
+				},
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"set_default_version": schema.BoolAttribute{
+				MarkdownDescription: "Whether a new version created by changing the document becomes the default version.  Turning it on makes the latest version the default.  Set to false when the default version is managed with `automation_aws_ssm_document_default_version`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"status": schema.StringAttribute{
+				Description: "The status of the document.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"tags": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"tags_all": schema.MapAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"target_type": schema.StringAttribute{
+				Description: "The type of resource the document can run on, e.g. /AWS::EC2::Instance.  Use / for all resource types.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtMost(200),
+					stringvalidator.RegexMatches(regexache.MustCompile(`^\/[\w\.\-\:\/]*$`), "must be a valid target type"),
+				},
+			},
+			"version_name": schema.StringAttribute{
+				Description: "The version name of the latest document version, e.g. a release of the artifact the document installs.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexache.MustCompile(`^[a-zA-Z0-9_\-.]{1,128}$`), "must contain only alphanumeric, underscore, hyphen, or period characters"),
+				},
+			},
+		},
+	}
+}
+
+// ValidateConfig parses JSON and YAML content and validates it against the schema of
+// the document type, so a malformed document fails the plan rather than the apply.
+func (a *AWSSSMDocumentResource) ValidateConfig(ctx context.Context, request resource.ValidateConfigRequest, response *resource.ValidateConfigResponse) {
+	var content DocumentContentValue
+	var documentFormat, documentType types.String
+
+	response.Diagnostics.Append(request.Config.GetAttribute(ctx, path.Root("content"), &content)...)
+	response.Diagnostics.Append(request.Config.GetAttribute(ctx, path.Root("document_format"), &documentFormat)...)
+	response.Diagnostics.Append(request.Config.GetAttribute(ctx, path.Root("document_type"), &documentType)...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	if content.IsNull() || content.IsUnknown() || documentFormat.IsUnknown() || documentType.IsUnknown() {
+		return
+	}
+
+	format := string(awstypes.DocumentFormatJson)
+	if !documentFormat.IsNull() {
+		format = documentFormat.ValueString()
+	}
+
+	// TEXT content, e.g. a change calendar, has no schema to validate.
+	if format == string(awstypes.DocumentFormatText) {
+		return
+	}
+
+	document, err := ssmdoc.ParseFormat(content.ValueString(), format)
+	if err != nil {
+		response.Diagnostics.AddAttributeError(path.Root("content"), "Invalid document content", err.Error())
+		return
+	}
+
+	for _, err := range document.Validate(documentType.ValueString()) {
+		response.Diagnostics.AddAttributeError(path.Root("content"), "Invalid document content", err.Error())
+	}
+}
+
+// ModifyPlan marks the version attributes unknown when the update creates a new
+// document version, plans the latest version as default when set_default_version is
+// turned on, and marks tags_all unknown when tags change.  A new version with unchanged
+// content is rejected at plan time.
+func (a *AWSSSMDocumentResource) ModifyPlan(ctx context.Context, request resource.ModifyPlanRequest, response *resource.ModifyPlanResponse) {
+	// Nothing to plan on create or destroy.
+	if request.State.Raw.IsNull() || request.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state AWSSSMDocumentResourceModel
+
+	response.Diagnostics.Append(request.Plan.Get(ctx, &plan)...)
+	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	if documentVersionChanged(plan, state) {
+		// AWS rejects a new version with the content of the latest one.
+		if !plan.Content.IsUnknown() && plan.DocumentFormat.Equal(state.DocumentFormat) && ssmdoc.Equal(plan.Content.ValueString(), state.Content.ValueString()) {
+			response.Diagnostics.AddAttributeError(
+				path.Root("content"),
+				"Unchanged document content",
+				"Every document version needs new content, change the content together with version_name, target_type or attachments.",
+			)
+			return
+		}
+
+		plan.DocumentVersion = types.StringUnknown()
+		plan.Status = types.StringUnknown()
+
+		if plan.SetDefaultVersion.ValueBool() {
+			plan.DefaultVersion = types.StringUnknown()
+		}
+	} else if documentDefaultVersionPromoted(plan, state) {
+		plan.DefaultVersion = state.DocumentVersion
+	}
+
+	if !plan.Tags.Equal(state.Tags) {
+		plan.TagsAll = types.MapUnknown(types.StringType)
+	}
+
+	response.Diagnostics.Append(response.Plan.Set(ctx, &plan)...)
+}
+
+func (a *AWSSSMDocumentResource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
+	var data AWSSSMDocumentResourceModel
+
+	response.Diagnostics.Append(request.Plan.Get(ctx, &data)...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	input := &ssm.CreateDocumentInput{
+		Content:        data.Content.ValueStringPointer(),
+		DocumentFormat: awstypes.DocumentFormat(data.DocumentFormat.ValueString()),
+		DocumentType:   awstypes.DocumentType(data.DocumentType.ValueString()),
+		Name:           data.Name.ValueStringPointer(),
+		Tags:           tagsIn(data.Tags.Elements()),
+		TargetType:     data.TargetType.ValueStringPointer(),
+		VersionName:    data.VersionName.ValueStringPointer(),
+	}
+
+	var diags diag.Diagnostics
+	input.Attachments, diags = documentAttachmentsIn(ctx, data.Attachments)
+	response.Diagnostics.Append(diags...)
+
+	input.Requires, diags = documentRequiresIn(ctx, data.Requires)
+	response.Diagnostics.Append(diags...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	output, err := a.Meta.AWSClient.SSMClient.CreateDocument(ctx, input)
+	if err != nil {
+		response.Diagnostics.AddError("Error creating document", err.Error())
+		return
+	}
+
+	description, err := waitDocumentActive(ctx, a.Meta.AWSClient.SSMClient, data.Name.ValueString(), aws.ToString(output.DocumentDescription.DocumentVersion), documentActiveTimeout)
+	if description == nil {
+		description = output.DocumentDescription
+	}
+
+	a.setDocumentComputed(&data, description)
+	SetFrameworkTags(&data.TagsAll, input.Tags, true)
+
+	response.Diagnostics.Append(response.State.Set(ctx, &data)...)
+
+	if err != nil {
+		response.Diagnostics.AddError("Error waiting for document", err.Error())
+	}
+}
+
+func (a *AWSSSMDocumentResource) Read(ctx context.Context, request resource.ReadRequest, response *resource.ReadResponse) {
+	var data AWSSSMDocumentResourceModel
+
+	response.Diagnostics.Append(request.State.Get(ctx, &data)...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	conn := a.Meta.AWSClient.SSMClient
+	name := data.Name.ValueString()
+
+	description, err := findDocumentByName(ctx, conn, name, "")
+	if err != nil {
+		response.Diagnostics.AddError("Error reading document", err.Error())
+		return
+	}
+
+	if description == nil {
+		response.State.RemoveResource(ctx)
+		return
+	}
+
+	// DescribeDocument describes the default version, but the content is that of the latest.
+	if aws.ToString(description.DocumentVersion) != aws.ToString(description.LatestVersion) {
+		description, err = findDocumentByName(ctx, conn, name, aws.ToString(description.LatestVersion))
+		if err != nil {
+			response.Diagnostics.AddError("Error reading document", err.Error())
+			return
+		}
+	}
+
+	content, err := conn.GetDocument(ctx, &ssm.GetDocumentInput{
+		DocumentFormat:  description.DocumentFormat,
+		DocumentVersion: description.DocumentVersion,
+		Name:            aws.String(name),
+	})
+	if err != nil {
+		response.Diagnostics.AddError("Error reading document content", err.Error())
+		return
+	}
+
+	tags, err := findTags(ctx, conn, awstypes.ResourceTypeForTaggingDocument, name)
+	if err != nil {
+		response.Diagnostics.AddError("Error reading document tags", err.Error())
+		return
+	}
+
+	SetFrameworkTags(&data.Tags, tags, false)
+
+	data.Content = NewDocumentContentValue(aws.ToString(content.Content))
+	SetFrameworkFromString(&data.DocumentFormat, string(description.DocumentFormat), false)
+	SetFrameworkFromString(&data.DocumentType, string(description.DocumentType), false)
+	SetFrameworkFromStringPointer(&data.Name, description.Name)
+	data.TargetType = types.StringPointerValue(description.TargetType)
+	data.VersionName = types.StringPointerValue(description.VersionName)
+
+	requires, diags := documentRequiresOut(ctx, description.Requires)
+	response.Diagnostics.Append(diags...)
+
+	if len(description.Requires) > 0 || !data.Requires.IsNull() {
+		data.Requires = requires
+	}
+
+	// Imported documents have no preference yet.
+	if data.SetDefaultVersion.IsNull() {
+		data.SetDefaultVersion = types.BoolValue(true)
+	}
+
+	// computed
+	a.setDocumentComputed(&data, description)
+	SetFrameworkTags(&data.TagsAll, tags, true)
+
+	response.Diagnostics.Append(response.State.Set(ctx, &data)...)
+}
+
+func (a *AWSSSMDocumentResource) Update(ctx context.Context, request resource.UpdateRequest, response *resource.UpdateResponse) {
+	var plan, state AWSSSMDocumentResourceModel
+
+	response.Diagnostics.Append(request.Plan.Get(ctx, &plan)...)
+	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	conn := a.Meta.AWSClient.SSMClient
+	name := state.Name.ValueString()
+
+	if documentVersionChanged(plan, state) {
+		// The planned content may be the prior content when the configured content is
+		// semantically equal, so send the configured content.
+		var content DocumentContentValue
+
+		response.Diagnostics.Append(request.Config.GetAttribute(ctx, path.Root("content"), &content)...)
+
+		input := &ssm.UpdateDocumentInput{
+			Content:         content.ValueStringPointer(),
+			DocumentFormat:  awstypes.DocumentFormat(plan.DocumentFormat.ValueString()),
+			DocumentVersion: aws.String("$LATEST"),
+			Name:            aws.String(name),
+			TargetType:      plan.TargetType.ValueStringPointer(),
+			VersionName:     plan.VersionName.ValueStringPointer(),
+		}
+
+		var diags diag.Diagnostics
+		input.Attachments, diags = documentAttachmentsIn(ctx, plan.Attachments)
+		response.Diagnostics.Append(diags...)
+
+		if response.Diagnostics.HasError() {
+			return
+		}
+
+		output, err := conn.UpdateDocument(ctx, input)
+		if errs.IsA[*awstypes.DuplicateDocumentContent](err) {
+			response.Diagnostics.AddError("Error updating document", fmt.Sprintf("%s\n\nEvery document version needs new content, change the content together with version_name, target_type or attachments.", err))
+			return
+		}
+		if err != nil {
+			response.Diagnostics.AddError("Error updating document", err.Error())
+			return
+		}
+
+		version := aws.ToString(output.DocumentDescription.DocumentVersion)
+
+		description, err := waitDocumentActive(ctx, conn, name, version, documentActiveTimeout)
+		if err != nil {
+			response.Diagnostics.AddError("Error waiting for document", err.Error())
+			return
+		}
+
+		if plan.SetDefaultVersion.ValueBool() {
+			_, err := conn.UpdateDocumentDefaultVersion(ctx, &ssm.UpdateDocumentDefaultVersionInput{
+				DocumentVersion: aws.String(version),
+				Name:            aws.String(name),
+			})
+			if err != nil {
+				response.Diagnostics.AddError("Error updating document default version", err.Error())
+				return
+			}
+
+			description.DefaultVersion = aws.String(version)
+		}
+
+		a.setDocumentComputed(&plan, description)
+	} else if documentDefaultVersionPromoted(plan, state) {
+		_, err := conn.UpdateDocumentDefaultVersion(ctx, &ssm.UpdateDocumentDefaultVersionInput{
+			DocumentVersion: state.DocumentVersion.ValueStringPointer(),
+			Name:            aws.String(name),
+		})
+		if err != nil {
+			response.Diagnostics.AddError("Error updating document default version", err.Error())
+			return
+		}
+
+		plan.DefaultVersion = state.DocumentVersion
+	}
+
+	if !plan.Tags.Equal(state.Tags) {
+		err := updateTags(ctx, conn, awstypes.ResourceTypeForTaggingDocument, name, state.Tags, plan.Tags)
+		if err != nil {
+			response.Diagnostics.AddError("Error updating document tags", err.Error())
+			return
+		}
+
+		plan.TagsAll = plan.Tags
+		if plan.TagsAll.IsNull() {
+			plan.TagsAll = types.MapValueMust(types.StringType, map[string]attr.Value{})
+		}
+	}
+
+	response.Diagnostics.Append(response.State.Set(ctx, &plan)...)
+}
+
+func (a *AWSSSMDocumentResource) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse) {
+	var data AWSSSMDocumentResourceModel
+
+	response.Diagnostics.Append(request.State.Get(ctx, &data)...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := a.Meta.AWSClient.SSMClient.DeleteDocument(ctx, &ssm.DeleteDocumentInput{
+		Name: data.Name.ValueStringPointer(),
+	})
+	if errs.IsA[*awstypes.InvalidDocument](err) {
+		return
+	}
+	if err != nil {
+		response.Diagnostics.AddError("Error deleting SSM document", err.Error())
+		return
+	}
+
+	if err := waitDocumentDeleted(ctx, a.Meta.AWSClient.SSMClient, data.Name.ValueString(), documentDeletedTimeout); err != nil {
+		response.Diagnostics.AddError("Error waiting for SSM document deletion", err.Error())
+	}
+}
+
+func (a *AWSSSMDocumentResource) ImportState(ctx context.Context, request resource.ImportStateRequest, response *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("name"), request, response)
+}
+
+func (a *AWSSSMDocumentResource) setDocumentComputed(data *AWSSSMDocumentResourceModel, description *awstypes.DocumentDescription) {
+	amazonResourceName := arn.ARN{
+		Partition: a.Meta.AWSClient.Partition,
+		Service:   "ssm",
+		Region:    a.Meta.AWSClient.Region,
+		AccountID: a.Meta.AWSClient.AccountID,
+		Resource:  "document/" + aws.ToString(description.Name),
+	}.String()
+
+	SetFrameworkFromString(&data.Arn, amazonResourceName, false)
+	if description.CreatedDate != nil {
+		SetFrameworkFromString(&data.CreatedDate, description.CreatedDate.Format(time.RFC3339), false)
+	}
+	SetFrameworkFromStringPointer(&data.DefaultVersion, description.DefaultVersion)
+	SetFrameworkFromStringPointer(&data.DocumentVersion, description.DocumentVersion)
+	SetFrameworkFromString(&data.Status, string(description.Status), false)
+}
+
+// documentVersionChanged reports whether updating the document from state to plan
+// creates a new document version.  Tags and set_default_version apply to the document.
+func documentVersionChanged(plan, state AWSSSMDocumentResourceModel) bool {
+	return !plan.Content.Equal(state.Content) ||
+		!plan.DocumentFormat.Equal(state.DocumentFormat) ||
+		!plan.TargetType.Equal(state.TargetType) ||
+		!plan.VersionName.Equal(state.VersionName) ||
+		!plan.Attachments.Equal(state.Attachments)
+}
+
+// documentDefaultVersionPromoted reports whether updating the document from state to
+// plan makes its latest version the default, as set_default_version is turned on while
+// an earlier version is the default.
+func documentDefaultVersionPromoted(plan, state AWSSSMDocumentResourceModel) bool {
+	return plan.SetDefaultVersion.ValueBool() && !state.SetDefaultVersion.ValueBool() &&
+		!state.DocumentVersion.IsNull() && !state.DefaultVersion.Equal(state.DocumentVersion)
+}
+
+func documentAttachmentsIn(ctx context.Context, list types.List) ([]awstypes.AttachmentsSource, diag.Diagnostics) {
+	if list.IsNull() || list.IsUnknown() {
+		return nil, nil
+	}
+
+	var attachments []DocumentAttachmentModel
+	diags := list.ElementsAs(ctx, &attachments, false)
+
+	result := make([]awstypes.AttachmentsSource, 0, len(attachments))
+
+	for _, attachment := range attachments {
+		source := awstypes.AttachmentsSource{
+			Key:  awstypes.AttachmentsSourceKey(attachment.Key.ValueString()),
+			Name: attachment.Name.ValueStringPointer(),
+		}
+		diags.Append(attachment.Values.ElementsAs(ctx, &source.Values, false)...)
+
+		result = append(result, source)
+	}
+
+	return result, diags
+}
+
+func documentRequiresIn(ctx context.Context, list types.List) ([]awstypes.DocumentRequires, diag.Diagnostics) {
+	if list.IsNull() || list.IsUnknown() {
+		return nil, nil
+	}
+
+	var requires []DocumentRequiresModel
+	diags := list.ElementsAs(ctx, &requires, false)
+
+	result := make([]awstypes.DocumentRequires, 0, len(requires))
+
+	for _, require := range requires {
+		result = append(result, awstypes.DocumentRequires{
+			Name:    require.Name.ValueStringPointer(),
+			Version: require.Version.ValueStringPointer(),
+		})
+	}
+
+	return result, diags
+}
+
+func documentRequiresOut(ctx context.Context, requires []awstypes.DocumentRequires) (types.List, diag.Diagnostics) {
+	result := make([]DocumentRequiresModel, 0, len(requires))
+
+	for _, require := range requires {
+		result = append(result, DocumentRequiresModel{
+			Name:    types.StringPointerValue(require.Name),
+			Version: types.StringPointerValue(require.Version),
+		})
+	}
+
+	return types.ListValueFrom(ctx, documentRequiresObjectType, result)
+}
+
+// findDocumentByName describes the document version, or the default version when the
+// version is empty.  The result is nil when the document does not exist.
+func findDocumentByName(ctx context.Context, conn *ssm.Client, name, version string) (*awstypes.DocumentDescription, error) {
+	input := &ssm.DescribeDocumentInput{
+		Name: aws.String(name),
+	}
+
+	if version != "" {
+		input.DocumentVersion = aws.String(version)
+	}
+
+	output, err := conn.DescribeDocument(ctx, input)
+	if err != nil {
+		if errs.IsA[*awstypes.InvalidDocument](err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return output.Document, nil
+}
+
+func waitDocumentActive(ctx context.Context, conn *ssm.Client, name, version string, timeout time.Duration) (*awstypes.DocumentDescription, error) {
+	stateConf := &retry.StateChangeConf{
+		Pending: []string{
+			string(awstypes.DocumentStatusCreating),
+			string(awstypes.DocumentStatusUpdating),
+		},
+		Target:  []string{string(awstypes.DocumentStatusActive)},
+		Refresh: statusDocument(ctx, conn, name, version),
+		Timeout: timeout,
+	}
+
+	outputRaw, err := stateConf.WaitForStateContext(ctx)
+
+	if output, ok := outputRaw.(*awstypes.DocumentDescription); ok {
+		if output.Status == awstypes.DocumentStatusFailed {
+			err = errors.New(aws.ToString(output.StatusInformation))
+		}
+
+		return output, err
+	}
+
+	return nil, err
+}
+
+func waitDocumentDeleted(ctx context.Context, conn *ssm.Client, name string, timeout time.Duration) error {
+	stateConf := &retry.StateChangeConf{
+		Pending: []string{
+			string(awstypes.DocumentStatusActive),
+			string(awstypes.DocumentStatusDeleting),
+		},
+		Target:  []string{},
+		Refresh: statusDocument(ctx, conn, name, ""),
+		Timeout: timeout,
+	}
+
+	_, err := stateConf.WaitForStateContext(ctx)
+
+	return err
+}
+
+func statusDocument(ctx context.Context, conn *ssm.Client, name, version string) retry.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		output, err := findDocumentByName(ctx, conn, name, version)
+
+		if err != nil {
+			return nil, "", err
+		}
+
+		if output == nil {
+			return nil, "", nil
+		}
+
+		return output, string(output.Status), nil
+	}
+}
@@ -0,0 +1,451 @@
+package provider
+
+import (
+	"cmp"
+	"context"
+	"github.com/YakDriver/regexache"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	awstypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/coding-ia/terraform-provider-automation/internal/ssmdoc"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"maps"
+	"slices"
+)
+
+// documentOwnerAmazon is the owner of the documents shared by AWS, which have no account in their ARN.
+const documentOwnerAmazon = "Amazon"
+
+var _ datasource.DataSource = &AWSSSMDocumentDataSource{}
+var _ datasource.DataSourceWithConfigure = &AWSSSMDocumentDataSource{}
+
+type AWSSSMDocumentDataSource struct {
+	Meta Meta
+}
+
+type AWSSSMDocumentDataSourceModel struct {
+	Arn             types.String `tfsdk:"arn"`
+	Content         types.String `tfsdk:"content"`
+	CreatedDate     types.String `tfsdk:"created_date"`
+	DefaultVersion  types.String `tfsdk:"default_version"`
+	Description     types.String `tfsdk:"description"`
+	DocumentFormat  types.String `tfsdk:"document_format"`
+	DocumentType    types.String `tfsdk:"document_type"`
+	DocumentVersion types.String `tfsdk:"document_version"`
+	LatestVersion   types.String `tfsdk:"latest_version"`
+	Name            types.String `tfsdk:"name"`
+	Owner           types.String `tfsdk:"owner"`
+	Parameters      types.List   `tfsdk:"parameters"`
+	PlatformTypes   types.List   `tfsdk:"platform_types"`
+	SchemaVersion   types.String `tfsdk:"schema_version"`
+	Status          types.String `tfsdk:"status"`
+	TargetType      types.String `tfsdk:"target_type"`
+	VersionName     types.String `tfsdk:"version_name"`
+	Versions        types.List   `tfsdk:"versions"`
+}
+
+type DocumentParameterModel struct {
+	AllowedPattern types.String `tfsdk:"allowed_pattern"`
+	AllowedValues  types.List   `tfsdk:"allowed_values"`
+	DefaultValues  types.List   `tfsdk:"default_values"`
+	Description    types.String `tfsdk:"description"`
+	Name           types.String `tfsdk:"name"`
+	Type           types.String `tfsdk:"type"`
+}
+
+type DocumentVersionModel struct {
+	CreatedDate      types.String `tfsdk:"created_date"`
+	DocumentVersion  types.String `tfsdk:"document_version"`
+	IsDefaultVersion types.Bool   `tfsdk:"is_default_version"`
+	Status           types.String `tfsdk:"status"`
+	VersionName      types.String `tfsdk:"version_name"`
+}
+
+var documentParameterObjectType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"allowed_pattern": types.StringType,
+		"allowed_values":  types.ListType{ElemType: types.StringType},
+		"default_values":  types.ListType{ElemType: types.StringType},
+		"description":     types.StringType,
+		"name":            types.StringType,
+		"type":            types.StringType,
+	},
+}
+
+var documentVersionObjectType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"created_date":       types.StringType,
+		"document_version":   types.StringType,
+		"is_default_version": types.BoolType,
+		"status":             types.StringType,
+		"version_name":       types.StringType,
+	},
+}
+
+func newAWSSSMDocumentDataSource() datasource.DataSource {
+	return &AWSSSMDocumentDataSource{}
+}
+
+func (a *AWSSSMDocumentDataSource) Configure(_ context.Context, request datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if request.ProviderData == nil {
+		return
+	}
+
+	a.Meta = request.ProviderData.(Meta)
+}
+
+func (a *AWSSSMDocumentDataSource) Metadata(_ context.Context, request datasource.MetadataRequest, response *datasource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_aws_ssm_document"
+}
+
+func (a *AWSSSMDocumentDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, response *datasource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		MarkdownDescription: "Reads an SSM Document, its versions and the parameters it takes.",
+		Attributes: map[string]schema.Attribute{
+			"arn": schema.StringAttribute{
+				Description: "The ARN of the document.",
+				Computed:    true,
+			},
+			"content": schema.StringAttribute{
+				Description: "The content of the document version in document_format.",
+				Computed:    true,
+			},
+			"created_date": schema.StringAttribute{
+				Description: "The time the document was created.",
+				Computed:    true,
+			},
+			"default_version": schema.StringAttribute{
+				Description: "The default version of the document.",
+				Computed:    true,
+			},
+			"description": schema.StringAttribute{
+				Description: "The description of the document.",
+				Computed:    true,
+			},
+			"document_format": schema.StringAttribute{
+				MarkdownDescription: "The format to return the content in, `JSON`, `YAML` or `TEXT`.  Defaults to the format the document was created in.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						string(awstypes.DocumentFormatJson),
+						string(awstypes.DocumentFormatYaml),
+						string(awstypes.DocumentFormatText),
+					),
+				},
+			},
+			"document_type": schema.StringAttribute{
+				Description: "The type of the document, e.g. Command or Automation.",
+				Computed:    true,
+			},
+			"document_version": schema.StringAttribute{
+				Description: "The version of the document to read.  Defaults to the default version.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexache.MustCompile(`^[1-9][0-9]*$`), "must be a document version number"),
+				},
+			},
+			"latest_version": schema.StringAttribute{
+				Description: "The latest version of the document.",
+				Computed:    true,
+			},
+			"name": schema.StringAttribute{
+				Description: "The name or ARN of the document.",
+				Required:    true,
+			},
+			"owner": schema.StringAttribute{
+				Description: "The account that owns the document, Amazon for documents shared by AWS.",
+				Computed:    true,
+			},
+			"parameters": schema.ListNestedAttribute{
+				Description: "The parameters of the document version, sorted by name.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"allowed_pattern": schema.StringAttribute{
+							Description: "The regular expression the value must match.",
+							Computed:    true,
+						},
+						"allowed_values": schema.ListAttribute{
+							Description: "The values the parameter accepts.",
+							Computed:    true,
+							ElementType: types.StringType,
+						},
+						"default_values": schema.ListAttribute{
+							Description: "The default value, in the form parameters are passed to associations and executions.  Null when the parameter is required.",
+							Computed:    true,
+							ElementType: types.StringType,
+						},
+						"description": schema.StringAttribute{
+							Description: "The description of the parameter.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "The name of the parameter.",
+							Computed:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "The type of the parameter, e.g. String, StringList or Integer.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"platform_types": schema.ListAttribute{
+				Description: "The operating systems the document runs on.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"schema_version": schema.StringAttribute{
+				Description: "The schema version of the document content.",
+				Computed:    true,
+			},
+			"status": schema.StringAttribute{
+				Description: "The status of the document.",
+				Computed:    true,
+			},
+			"target_type": schema.StringAttribute{
+				Description: "The resource type the document runs on.",
+				Computed:    true,
+			},
+			"version_name": schema.StringAttribute{
+				Description: "The version name of the document version.",
+				Computed:    true,
+			},
+			"versions": schema.ListNestedAttribute{
+				Description: "All versions of the document.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"created_date": schema.StringAttribute{
+							Description: "The time the version was created.",
+							Computed:    true,
+						},
+						"document_version": schema.StringAttribute{
+							Description: "The version of the document.",
+							Computed:    true,
+						},
+						"is_default_version": schema.BoolAttribute{
+							Description: "Whether the version is the default version.",
+							Computed:    true,
+						},
+						"status": schema.StringAttribute{
+							Description: "The status of the version.",
+							Computed:    true,
+						},
+						"version_name": schema.StringAttribute{
+							Description: "The version name of the version.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (a *AWSSSMDocumentDataSource) Read(ctx context.Context, request datasource.ReadRequest, response *datasource.ReadResponse) {
+	var data AWSSSMDocumentDataSourceModel
+
+	response.Diagnostics.Append(request.Config.Get(ctx, &data)...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	conn := a.Meta.AWSClient.SSMClient
+	name := data.Name.ValueString()
+
+	description, err := findDocumentByName(ctx, conn, name, data.DocumentVersion.ValueString())
+	if err != nil {
+		response.Diagnostics.AddError("Error reading document", err.Error())
+		return
+	}
+
+	if description == nil {
+		response.Diagnostics.AddError("Error reading document", "document "+name+" not found")
+		return
+	}
+
+	if data.DocumentFormat.IsNull() {
+		data.DocumentFormat = types.StringValue(string(description.DocumentFormat))
+	}
+
+	content, err := conn.GetDocument(ctx, &ssm.GetDocumentInput{
+		DocumentFormat:  awstypes.DocumentFormat(data.DocumentFormat.ValueString()),
+		DocumentVersion: description.DocumentVersion,
+		Name:            aws.String(name),
+	})
+	if err != nil {
+		response.Diagnostics.AddError("Error reading document content", err.Error())
+		return
+	}
+
+	data.Content = types.StringPointerValue(content.Content)
+
+	parameters, err := documentParameters(ctx, conn, name, description)
+	if err != nil {
+		response.Diagnostics.AddError("Error reading document parameters", err.Error())
+		return
+	}
+
+	var diags diag.Diagnostics
+	data.Parameters, diags = types.ListValueFrom(ctx, documentParameterObjectType, parameters)
+	response.Diagnostics.Append(diags...)
+
+	versions, err := findDocumentVersions(ctx, conn, name)
+	if err != nil {
+		response.Diagnostics.AddError("Error reading document versions", err.Error())
+		return
+	}
+
+	data.Versions, diags = types.ListValueFrom(ctx, documentVersionObjectType, versions)
+	response.Diagnostics.Append(diags...)
+
+	platformTypes := make([]string, 0, len(description.PlatformTypes))
+	for _, platformType := range description.PlatformTypes {
+		platformTypes = append(platformTypes, string(platformType))
+	}
+
+	data.PlatformTypes, diags = types.ListValueFrom(ctx, types.StringType, platformTypes)
+	response.Diagnostics.Append(diags...)
+
+	accountID := a.Meta.AWSClient.AccountID
+	if aws.ToString(description.Owner) == documentOwnerAmazon {
+		accountID = ""
+	}
+
+	data.Arn = types.StringValue(arn.ARN{
+		Partition: a.Meta.AWSClient.Partition,
+		Service:   "ssm",
+		Region:    a.Meta.AWSClient.Region,
+		AccountID: accountID,
+		Resource:  "document/" + aws.ToString(description.Name),
+	}.String())
+	data.CreatedDate = timeOut(description.CreatedDate)
+	data.DefaultVersion = types.StringPointerValue(description.DefaultVersion)
+	data.Description = types.StringPointerValue(description.Description)
+	data.DocumentType = types.StringValue(string(description.DocumentType))
+	data.DocumentVersion = types.StringPointerValue(description.DocumentVersion)
+	data.LatestVersion = types.StringPointerValue(description.LatestVersion)
+	data.Owner = types.StringPointerValue(description.Owner)
+	data.SchemaVersion = types.StringPointerValue(description.SchemaVersion)
+	data.Status = types.StringValue(string(description.Status))
+	data.TargetType = types.StringPointerValue(description.TargetType)
+	data.VersionName = types.StringPointerValue(description.VersionName)
+
+	response.Diagnostics.Append(response.State.Set(ctx, &data)...)
+}
+
+// documentParameters returns the parameters of a document version.  The content holds the
+// full parameter definitions, DescribeDocument only reports String and StringList types.
+func documentParameters(ctx context.Context, conn *ssm.Client, name string, description *awstypes.DocumentDescription) ([]DocumentParameterModel, error) {
+	if description.DocumentFormat == awstypes.DocumentFormatText {
+		return describedDocumentParameters(ctx, description.Parameters)
+	}
+
+	document, err := findDocumentContent(ctx, conn, name, aws.ToString(description.DocumentVersion))
+	if err != nil {
+		return nil, err
+	}
+
+	return documentParametersOut(ctx, document)
+}
+
+func documentParametersOut(ctx context.Context, document *ssmdoc.Document) ([]DocumentParameterModel, error) {
+	parameters := make([]DocumentParameterModel, 0, len(document.Parameters))
+
+	for _, name := range slices.Sorted(maps.Keys(document.Parameters)) {
+		parameter := document.Parameters[name]
+
+		defaults, err := parameter.DefaultValues()
+		if err != nil {
+			return nil, err
+		}
+
+		defaultValues := types.ListNull(types.StringType)
+		if defaults != nil {
+			defaultValues, _ = types.ListValueFrom(ctx, types.StringType, defaults)
+		}
+
+		allowedValues, _ := types.ListValueFrom(ctx, types.StringType, parameter.AllowedValueStrings())
+
+		model := DocumentParameterModel{
+			AllowedValues: allowedValues,
+			DefaultValues: defaultValues,
+			Name:          types.StringValue(name),
+			Type:          types.StringValue(parameter.Type),
+		}
+
+		SetFrameworkFromString(&model.AllowedPattern, parameter.AllowedPattern, true)
+		SetFrameworkFromString(&model.Description, parameter.Description, true)
+
+		parameters = append(parameters, model)
+	}
+
+	return parameters, nil
+}
+
+func describedDocumentParameters(ctx context.Context, described []awstypes.DocumentParameter) ([]DocumentParameterModel, error) {
+	parameters := make([]DocumentParameterModel, 0, len(described))
+
+	for _, parameter := range described {
+		defaultValues := types.ListNull(types.StringType)
+		if parameter.DefaultValue != nil {
+			defaultValues, _ = types.ListValueFrom(ctx, types.StringType, []string{aws.ToString(parameter.DefaultValue)})
+		}
+
+		allowedValues, _ := types.ListValueFrom(ctx, types.StringType, []string{})
+
+		parameters = append(parameters, DocumentParameterModel{
+			AllowedPattern: types.StringNull(),
+			AllowedValues:  allowedValues,
+			DefaultValues:  defaultValues,
+			Description:    types.StringPointerValue(parameter.Description),
+			Name:           types.StringPointerValue(parameter.Name),
+			Type:           types.StringValue(string(parameter.Type)),
+		})
+	}
+
+	slices.SortFunc(parameters, func(a, b DocumentParameterModel) int {
+		return cmp.Compare(a.Name.ValueString(), b.Name.ValueString())
+	})
+
+	return parameters, nil
+}
+
+func findDocumentVersions(ctx context.Context, conn *ssm.Client, name string) ([]DocumentVersionModel, error) {
+	input := &ssm.ListDocumentVersionsInput{
+		Name: aws.String(name),
+	}
+
+	versions := []DocumentVersionModel{}
+
+	pages := ssm.NewListDocumentVersionsPaginator(conn, input)
+	for pages.HasMorePages() {
+		page, err := pages.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, version := range page.DocumentVersions {
+			versions = append(versions, DocumentVersionModel{
+				CreatedDate:      timeOut(version.CreatedDate),
+				DocumentVersion:  types.StringPointerValue(version.DocumentVersion),
+				IsDefaultVersion: types.BoolValue(version.IsDefaultVersion),
+				Status:           types.StringValue(string(version.Status)),
+				VersionName:      types.StringPointerValue(version.VersionName),
+			})
+		}
+	}
+
+	return versions, nil
+}
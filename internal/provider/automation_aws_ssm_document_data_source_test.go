@@ -0,0 +1,183 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/coding-ia/terraform-provider-automation/internal/ssmdoc"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"slices"
+	"testing"
+)
+
+func TestAccSSMDocumentDataSource_basic(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "automation_aws_ssm_document.test"
+	dataSourceName := "data.automation_aws_ssm_document.test"
+	yamlName := "data.automation_aws_ssm_document.yaml"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDocumentDataSourceConfig_basic(rName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dataSourceName, "arn", resourceName, "arn"),
+					resource.TestCheckResourceAttr(dataSourceName, "document_format", "JSON"),
+					resource.TestCheckResourceAttr(dataSourceName, "document_type", "Command"),
+					resource.TestCheckResourceAttr(dataSourceName, "document_version", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "default_version", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "latest_version", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "schema_version", "2.2"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "owner"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "content"),
+					resource.TestCheckResourceAttr(dataSourceName, "versions.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "versions.0.is_default_version", "true"),
+					resource.TestCheckResourceAttr(dataSourceName, "parameters.#", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "parameters.0.name", "Level"),
+					resource.TestCheckResourceAttr(dataSourceName, "parameters.0.type", "String"),
+					resource.TestCheckResourceAttr(dataSourceName, "parameters.0.allowed_values.#", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "parameters.0.default_values.0", "info"),
+					resource.TestCheckResourceAttr(dataSourceName, "parameters.1.name", "Message"),
+					resource.TestCheckResourceAttr(dataSourceName, "parameters.1.description", "The message to echo."),
+					resource.TestCheckNoResourceAttr(dataSourceName, "parameters.1.default_values"),
+					resource.TestCheckResourceAttr(yamlName, "document_format", "YAML"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSSMDocumentDataSource_shared(t *testing.T) {
+	dataSourceName := "data.automation_aws_ssm_document.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "automation_aws_ssm_document" "test" {
+  name = "AWS-RunShellScript"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "owner", "Amazon"),
+					resource.TestCheckResourceAttr(dataSourceName, "document_type", "Command"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "parameters.#"),
+				),
+			},
+		},
+	})
+}
+
+func TestDocumentParametersOut(t *testing.T) {
+	document, err := ssmdoc.Parse(`{
+  "schemaVersion": "2.2",
+  "parameters": {
+    "Message": {"type": "String", "description": "The message."},
+    "Count": {"type": "Integer", "default": 3},
+    "Level": {"type": "String", "default": "info", "allowedValues": ["info", "debug"], "allowedPattern": "^[a-z]+$"},
+    "Commands": {"type": "StringList", "default": ["a", "b"]}
+  }
+}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	parameters, err := documentParametersOut(context.Background(), document)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var names []string
+	for _, parameter := range parameters {
+		names = append(names, parameter.Name.ValueString())
+	}
+
+	if want := []string{"Commands", "Count", "Level", "Message"}; !slices.Equal(names, want) {
+		t.Fatalf("expected %v, got %v", want, names)
+	}
+
+	testCases := []struct {
+		defaults []string
+		allowed  []string
+		pattern  string
+		typ      string
+	}{
+		{defaults: []string{"a", "b"}, typ: "StringList"},
+		{defaults: []string{"3"}, typ: "Integer"},
+		{defaults: []string{"info"}, allowed: []string{"info", "debug"}, pattern: "^[a-z]+$", typ: "String"},
+		{typ: "String"},
+	}
+
+	for i, testCase := range testCases {
+		parameter := parameters[i]
+
+		var defaults, allowed []string
+		parameter.DefaultValues.ElementsAs(context.Background(), &defaults, false)
+		parameter.AllowedValues.ElementsAs(context.Background(), &allowed, false)
+
+		if !slices.Equal(defaults, testCase.defaults) {
+			t.Errorf("%s: expected defaults %v, got %v", names[i], testCase.defaults, defaults)
+		}
+
+		if testCase.defaults == nil && !parameter.DefaultValues.IsNull() {
+			t.Errorf("%s: expected null defaults", names[i])
+		}
+
+		if !slices.Equal(allowed, testCase.allowed) {
+			t.Errorf("%s: expected allowed values %v, got %v", names[i], testCase.allowed, allowed)
+		}
+
+		if parameter.AllowedPattern.ValueString() != testCase.pattern {
+			t.Errorf("%s: expected allowed pattern %q, got %q", names[i], testCase.pattern, parameter.AllowedPattern.ValueString())
+		}
+
+		if parameter.Type.ValueString() != testCase.typ {
+			t.Errorf("%s: expected type %q, got %q", names[i], testCase.typ, parameter.Type.ValueString())
+		}
+	}
+}
+
+func testAccDocumentDataSourceConfig_basic(rName string) string {
+	return fmt.Sprintf(`
+resource "automation_aws_ssm_document" "test" {
+  name          = %[1]q
+  document_type = "Command"
+
+  content = jsonencode({
+    schemaVersion = "2.2"
+    description   = "Echo a message."
+    parameters = {
+      Message = {
+        type        = "String"
+        description = "The message to echo."
+      }
+      Level = {
+        type          = "String"
+        default       = "info"
+        allowedValues = ["info", "debug"]
+      }
+    }
+    mainSteps = [{
+      action = "aws:runShellScript"
+      name   = "echo"
+      inputs = {
+        runCommand = ["echo {{ Level }} {{ Message }}"]
+      }
+    }]
+  })
+}
+
+data "automation_aws_ssm_document" "test" {
+  name = automation_aws_ssm_document.test.name
+}
+
+data "automation_aws_ssm_document" "yaml" {
+  name            = automation_aws_ssm_document.test.name
+  document_format = "YAML"
+}
+`, rName)
+}
@@ -0,0 +1,162 @@
+package provider
+
+import (
+	"context"
+	"github.com/YakDriver/regexache"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.Resource = &AWSSSMDocumentDefaultVersionResource{}
+var _ resource.ResourceWithConfigure = &AWSSSMDocumentDefaultVersionResource{}
+var _ resource.ResourceWithImportState = &AWSSSMDocumentDefaultVersionResource{}
+
+type AWSSSMDocumentDefaultVersionResource struct {
+	Meta Meta
+}
+
+type AWSSSMDocumentDefaultVersionResourceModel struct {
+	DocumentVersion types.String `tfsdk:"document_version"`
+	Name            types.String `tfsdk:"name"`
+	VersionName     types.String `tfsdk:"version_name"`
+}
+
+func newAWSSSMDocumentDefaultVersionResource() resource.Resource {
+	return &AWSSSMDocumentDefaultVersionResource{}
+}
+
+func (a *AWSSSMDocumentDefaultVersionResource) Configure(_ context.Context, request resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if request.ProviderData == nil {
+		return
+	}
+
+	a.Meta = request.ProviderData.(Meta)
+}
+
+func (a *AWSSSMDocumentDefaultVersionResource) Metadata(_ context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_aws_ssm_document_default_version"
+}
+
+func (a *AWSSSMDocumentDefaultVersionResource) Schema(_ context.Context, _ resource.SchemaRequest, response *resource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		MarkdownDescription: "Sets the default version of an SSM Document, e.g. to promote a tested runbook version.  Associations and executions following `$DEFAULT` run the default version.  Destroying the resource leaves the default version unchanged.",
+		Attributes: map[string]schema.Attribute{
+			"document_version": schema.StringAttribute{
+				Description: "The version of the document to make the default version.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexache.MustCompile(`^[1-9][0-9]*$`), "must be a document version number"),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the document.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexache.MustCompile(`^[0-9A-Za-z_.-]{3,128}$`), "must contain only alphanumeric, underscore, hyphen, or period characters"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"version_name": schema.StringAttribute{
+				Description: "The version name of the default version.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (a *AWSSSMDocumentDefaultVersionResource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
+	var data AWSSSMDocumentDefaultVersionResourceModel
+
+	response.Diagnostics.Append(request.Plan.Get(ctx, &data)...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	if err := updateDocumentDefaultVersion(ctx, a.Meta.AWSClient.SSMClient, &data); err != nil {
+		response.Diagnostics.AddError("Error setting document default version", err.Error())
+		return
+	}
+
+	response.Diagnostics.Append(response.State.Set(ctx, &data)...)
+}
+
+func (a *AWSSSMDocumentDefaultVersionResource) Read(ctx context.Context, request resource.ReadRequest, response *resource.ReadResponse) {
+	var data AWSSSMDocumentDefaultVersionResourceModel
+
+	response.Diagnostics.Append(request.State.Get(ctx, &data)...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	document, err := findDocumentByName(ctx, a.Meta.AWSClient.SSMClient, data.Name.ValueString(), "")
+	if err != nil {
+		response.Diagnostics.AddError("Error reading document", err.Error())
+		return
+	}
+
+	if document == nil {
+		response.State.RemoveResource(ctx)
+		return
+	}
+
+	SetFrameworkFromStringPointer(&data.DocumentVersion, document.DefaultVersion)
+	SetFrameworkFromStringPointer(&data.Name, document.Name)
+	data.VersionName = types.StringPointerValue(document.VersionName)
+
+	response.Diagnostics.Append(response.State.Set(ctx, &data)...)
+}
+
+func (a *AWSSSMDocumentDefaultVersionResource) Update(ctx context.Context, request resource.UpdateRequest, response *resource.UpdateResponse) {
+	var plan AWSSSMDocumentDefaultVersionResourceModel
+
+	response.Diagnostics.Append(request.Plan.Get(ctx, &plan)...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	if err := updateDocumentDefaultVersion(ctx, a.Meta.AWSClient.SSMClient, &plan); err != nil {
+		response.Diagnostics.AddError("Error setting document default version", err.Error())
+		return
+	}
+
+	response.Diagnostics.Append(response.State.Set(ctx, &plan)...)
+}
+
+// Delete only removes the resource from state, a document always has a default version.
+func (a *AWSSSMDocumentDefaultVersionResource) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+}
+
+func (a *AWSSSMDocumentDefaultVersionResource) ImportState(ctx context.Context, request resource.ImportStateRequest, response *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("name"), request, response)
+}
+
+func updateDocumentDefaultVersion(ctx context.Context, conn *ssm.Client, data *AWSSSMDocumentDefaultVersionResourceModel) error {
+	output, err := conn.UpdateDocumentDefaultVersion(ctx, &ssm.UpdateDocumentDefaultVersionInput{
+		DocumentVersion: data.DocumentVersion.ValueStringPointer(),
+		Name:            data.Name.ValueStringPointer(),
+	})
+	if err != nil {
+		return err
+	}
+
+	data.VersionName = types.StringNull()
+
+	if output.Description != nil {
+		SetFrameworkFromStringPointer(&data.DocumentVersion, output.Description.DefaultVersion)
+		data.VersionName = types.StringPointerValue(output.Description.DefaultVersionName)
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"testing"
+)
+
+func TestAccSSMDocumentDefaultVersion_basic(t *testing.T) {
+	ctx := context.Background()
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "automation_aws_ssm_document_default_version.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDocumentDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDocumentDefaultVersionConfig_basic(rName, "hello", "1.0", "1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckDocumentDefaultVersion(ctx, rName, "1"),
+					resource.TestCheckResourceAttr(resourceName, "document_version", "1"),
+					resource.TestCheckResourceAttr(resourceName, "version_name", "1.0"),
+				),
+			},
+			{
+				// Publish version 2 without promoting it.
+				Config: testAccDocumentDefaultVersionConfig_basic(rName, "goodbye", "2.0", "1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckDocumentDefaultVersion(ctx, rName, "1"),
+					resource.TestCheckResourceAttr("automation_aws_ssm_document.test", "document_version", "2"),
+					resource.TestCheckResourceAttr(resourceName, "document_version", "1"),
+				),
+			},
+			{
+				Config: testAccDocumentDefaultVersionConfig_basic(rName, "goodbye", "2.0", "2"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckDocumentDefaultVersion(ctx, rName, "2"),
+					resource.TestCheckResourceAttr(resourceName, "document_version", "2"),
+					resource.TestCheckResourceAttr(resourceName, "version_name", "2.0"),
+				),
+			},
+			{
+				ResourceName:                         resourceName,
+				ImportState:                          true,
+				ImportStateId:                        rName,
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "name",
+			},
+		},
+	})
+}
+
+func testAccDocumentDefaultVersionConfig_basic(rName, message, versionName, defaultVersion string) string {
+	return fmt.Sprintf(`
+resource "automation_aws_ssm_document" "test" {
+  name                = %[1]q
+  document_type       = "Command"
+  content             = %[2]s
+  version_name        = %[3]q
+  set_default_version = false
+}
+
+resource "automation_aws_ssm_document_default_version" "test" {
+  name             = automation_aws_ssm_document.test.name
+  document_version = %[4]q
+}
+`, rName, testAccDocumentCommandContent(message), versionName, defaultVersion)
+}
+
+func testAccCheckDocumentDefaultVersion(ctx context.Context, name, version string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := getProviderMeta(ctx).AWSClient.SSMClient
+
+		document, err := findDocumentByName(ctx, conn, name, "")
+		if err != nil {
+			return err
+		}
+
+		if document == nil {
+			return fmt.Errorf("SSM document %s not found", name)
+		}
+
+		if got := aws.ToString(document.DefaultVersion); got != version {
+			return fmt.Errorf("SSM document %s default version is %s, expected %s", name, got, version)
+		}
+
+		return nil
+	}
+}
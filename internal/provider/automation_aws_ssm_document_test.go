@@ -0,0 +1,302 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/YakDriver/regexache"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"testing"
+)
+
+func TestAccSSMDocument_basic(t *testing.T) {
+	ctx := context.Background()
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "automation_aws_ssm_document.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDocumentDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDocumentConfig_basic(rName, "hello"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckDocumentExists(ctx, resourceName),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "document_type", "Command"),
+					resource.TestCheckResourceAttr(resourceName, "document_format", "JSON"),
+					resource.TestCheckResourceAttr(resourceName, "document_version", "1"),
+					resource.TestCheckResourceAttr(resourceName, "default_version", "1"),
+					resource.TestCheckResourceAttr(resourceName, "status", "Active"),
+					resource.TestCheckResourceAttr(resourceName, "tags.Name", rName),
+					resource.TestMatchResourceAttr(resourceName, "arn", regexache.MustCompile(`:document/`+rName+`$`)),
+				),
+			},
+			{
+				ResourceName:                         resourceName,
+				ImportState:                          true,
+				ImportStateId:                        rName,
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "name",
+			},
+			{
+				Config: testAccDocumentConfig_basic(rName, "goodbye"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionUpdate),
+						plancheck.ExpectUnknownValue(resourceName, tfjsonpath.New("document_version")),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "document_version", "2"),
+					resource.TestCheckResourceAttr(resourceName, "default_version", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSSMDocument_yaml(t *testing.T) {
+	ctx := context.Background()
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "automation_aws_ssm_document.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDocumentDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDocumentConfig_yaml(rName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckDocumentExists(ctx, resourceName),
+					resource.TestCheckResourceAttr(resourceName, "document_type", "Automation"),
+					resource.TestCheckResourceAttr(resourceName, "document_format", "YAML"),
+				),
+			},
+			{
+				Config: testAccDocumentConfig_yaml(rName),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestAccSSMDocument_setDefaultVersion(t *testing.T) {
+	ctx := context.Background()
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "automation_aws_ssm_document.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDocumentDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDocumentConfig_setDefaultVersion(rName, "hello", false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "document_version", "1"),
+					resource.TestCheckResourceAttr(resourceName, "default_version", "1"),
+				),
+			},
+			{
+				Config: testAccDocumentConfig_setDefaultVersion(rName, "goodbye", false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "document_version", "2"),
+					resource.TestCheckResourceAttr(resourceName, "default_version", "1"),
+				),
+			},
+			{
+				Config: testAccDocumentConfig_setDefaultVersion(rName, "goodbye", true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "document_version", "2"),
+					resource.TestCheckResourceAttr(resourceName, "default_version", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSSMDocument_unchangedContent(t *testing.T) {
+	ctx := context.Background()
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDocumentDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDocumentConfig_versionName(rName, "hello", "v1"),
+			},
+			{
+				Config:      testAccDocumentConfig_versionName(rName, "hello", "v2"),
+				ExpectError: regexache.MustCompile(`Unchanged document content`),
+			},
+		},
+	})
+}
+
+func TestAccSSMDocument_invalidContent(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccDocumentConfig_content(rName, "Command", `{"schemaVersion": "2.2", "mainSteps": [{"name": "echo"}]}`),
+				PlanOnly:    true,
+				ExpectError: regexache.MustCompile(`mainSteps\[0\]: action is required`),
+			},
+			{
+				Config:      testAccDocumentConfig_content(rName, "Automation", `{"schemaVersion": "2.2"`),
+				PlanOnly:    true,
+				ExpectError: regexache.MustCompile(`Invalid document content`),
+			},
+		},
+	})
+}
+
+func testAccDocumentCommandContent(message string) string {
+	return fmt.Sprintf(`jsonencode({
+    schemaVersion = "2.2"
+    description   = "Test document"
+    parameters = {
+      Message = {
+        type    = "String"
+        default = %[1]q
+      }
+    }
+    mainSteps = [{
+      action = "aws:runShellScript"
+      name   = "echo"
+      inputs = {
+        runCommand = ["echo {{ Message }}"]
+      }
+    }]
+  })`, message)
+}
+
+func testAccDocumentConfig_basic(rName, message string) string {
+	return fmt.Sprintf(`
+resource "automation_aws_ssm_document" "test" {
+  name          = %[1]q
+  document_type = "Command"
+  content       = %[2]s
+
+  tags = {
+    Name = %[1]q
+  }
+}
+`, rName, testAccDocumentCommandContent(message))
+}
+
+func testAccDocumentConfig_setDefaultVersion(rName, message string, setDefaultVersion bool) string {
+	return fmt.Sprintf(`
+resource "automation_aws_ssm_document" "test" {
+  name                = %[1]q
+  document_type       = "Command"
+  content             = %[2]s
+  set_default_version = %[3]t
+}
+`, rName, testAccDocumentCommandContent(message), setDefaultVersion)
+}
+
+func testAccDocumentConfig_versionName(rName, message, versionName string) string {
+	return fmt.Sprintf(`
+resource "automation_aws_ssm_document" "test" {
+  name          = %[1]q
+  document_type = "Command"
+  content       = %[2]s
+  version_name  = %[3]q
+}
+`, rName, testAccDocumentCommandContent(message), versionName)
+}
+
+func testAccDocumentConfig_yaml(rName string) string {
+	return fmt.Sprintf(`
+resource "automation_aws_ssm_document" "test" {
+  name            = %[1]q
+  document_type   = "Automation"
+  document_format = "YAML"
+
+  content = <<-EOT
+    schemaVersion: '0.3'
+    description: Test runbook
+    parameters:
+      Duration:
+        type: String
+        default: PT1S
+    mainSteps:
+      - name: sleep
+        action: aws:sleep
+        inputs:
+          Duration: '{{ Duration }}'
+  EOT
+}
+`, rName)
+}
+
+func testAccDocumentConfig_content(rName, documentType, content string) string {
+	return fmt.Sprintf(`
+resource "automation_aws_ssm_document" "test" {
+  name          = %[1]q
+  document_type = %[2]q
+  content       = %[3]q
+}
+`, rName, documentType, content)
+}
+
+func testAccCheckDocumentExists(ctx context.Context, n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		conn := getProviderMeta(ctx).AWSClient.SSMClient
+
+		document, err := findDocumentByName(ctx, conn, rs.Primary.Attributes["name"], "")
+		if err != nil {
+			return err
+		}
+
+		if document == nil {
+			return fmt.Errorf("SSM document %s not found", rs.Primary.Attributes["name"])
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckDocumentDestroy(ctx context.Context) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := getProviderMeta(ctx).AWSClient.SSMClient
+
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "automation_aws_ssm_document" {
+				continue
+			}
+
+			document, err := findDocumentByName(ctx, conn, rs.Primary.Attributes["name"], "")
+			if err != nil {
+				return err
+			}
+
+			if document != nil {
+				return fmt.Errorf("SSM document %s still exists", rs.Primary.Attributes["name"])
+			}
+		}
+
+		return nil
+	}
+}
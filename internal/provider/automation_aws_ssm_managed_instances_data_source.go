@@ -0,0 +1,430 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/service/resourcegroups"
+	rgtypes "github.com/aws/aws-sdk-go-v2/service/resourcegroups/types"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	awstypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"slices"
+	"strings"
+)
+
+// Target keys that can be resolved to managed instances.
+const (
+	targetKeyInstanceIds        = "InstanceIds"
+	targetKeyParameterValues    = "ParameterValues"
+	targetKeyResourceGroupName  = "resource-groups:Name"
+	targetKeyResourceGroupTypes = "resource-groups:ResourceTypeFilters"
+	targetKeyTagKey             = "tag-key"
+	targetKeyTagPrefix          = "tag:"
+)
+
+// instanceIdsFilterMaxValues is the number of instance IDs sent per DescribeInstanceInformation filter.
+const instanceIdsFilterMaxValues = 50
+
+var _ datasource.DataSource = &AWSSSMManagedInstancesDataSource{}
+var _ datasource.DataSourceWithConfigure = &AWSSSMManagedInstancesDataSource{}
+
+type AWSSSMManagedInstancesDataSource struct {
+	Meta Meta
+}
+
+type AWSSSMManagedInstancesDataSourceModel struct {
+	InstanceIds types.List   `tfsdk:"instance_ids"`
+	Instances   types.List   `tfsdk:"instances"`
+	OnlineCount types.Int64  `tfsdk:"online_count"`
+	Targets     TargetsValue `tfsdk:"targets"`
+}
+
+type ManagedInstanceModel struct {
+	AgentVersion     types.String `tfsdk:"agent_version"`
+	ComputerName     types.String `tfsdk:"computer_name"`
+	InstanceId       types.String `tfsdk:"instance_id"`
+	IpAddress        types.String `tfsdk:"ip_address"`
+	IsLatestVersion  types.Bool   `tfsdk:"is_latest_version"`
+	LastPingDateTime types.String `tfsdk:"last_ping_date_time"`
+	PingStatus       types.String `tfsdk:"ping_status"`
+	PlatformName     types.String `tfsdk:"platform_name"`
+	PlatformType     types.String `tfsdk:"platform_type"`
+	PlatformVersion  types.String `tfsdk:"platform_version"`
+	ResourceType     types.String `tfsdk:"resource_type"`
+}
+
+var managedInstanceObjectType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"agent_version":       types.StringType,
+		"computer_name":       types.StringType,
+		"instance_id":         types.StringType,
+		"ip_address":          types.StringType,
+		"is_latest_version":   types.BoolType,
+		"last_ping_date_time": types.StringType,
+		"ping_status":         types.StringType,
+		"platform_name":       types.StringType,
+		"platform_type":       types.StringType,
+		"platform_version":    types.StringType,
+		"resource_type":       types.StringType,
+	},
+}
+
+// instanceTargetQuery is a targets expression translated into a DescribeInstanceInformation
+// query.  Targets are combined, an instance has to match every target.
+type instanceTargetQuery struct {
+	filters []awstypes.InstanceInformationStringFilter
+
+	// instanceIds restricts the instances when restricted is set, an empty list then
+	// matches no instance.
+	instanceIds []string
+	restricted  bool
+
+	resourceGroup string
+	resourceTypes []string
+}
+
+func newAWSSSMManagedInstancesDataSource() datasource.DataSource {
+	return &AWSSSMManagedInstancesDataSource{}
+}
+
+func (a *AWSSSMManagedInstancesDataSource) Configure(_ context.Context, request datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if request.ProviderData == nil {
+		return
+	}
+
+	a.Meta = request.ProviderData.(Meta)
+}
+
+func (a *AWSSSMManagedInstancesDataSource) Metadata(_ context.Context, request datasource.MetadataRequest, response *datasource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_aws_ssm_managed_instances"
+}
+
+func (a *AWSSSMManagedInstancesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, response *datasource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		MarkdownDescription: "Resolves a `targets` expression, as used by associations and executions, to the SSM managed instances it targets.",
+		Attributes: map[string]schema.Attribute{
+			"instance_ids": schema.ListAttribute{
+				Description: "The IDs of the targeted managed instances.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"instances": schema.ListNestedAttribute{
+				Description: "The targeted managed instances.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"agent_version": schema.StringAttribute{
+							Description: "The version of the SSM Agent.",
+							Computed:    true,
+						},
+						"computer_name": schema.StringAttribute{
+							Description: "The fully qualified host name of the instance.",
+							Computed:    true,
+						},
+						"instance_id": schema.StringAttribute{
+							Description: "The ID of the instance.",
+							Computed:    true,
+						},
+						"ip_address": schema.StringAttribute{
+							Description: "The IP address of the instance.",
+							Computed:    true,
+						},
+						"is_latest_version": schema.BoolAttribute{
+							Description: "Whether the latest version of the SSM Agent is installed.",
+							Computed:    true,
+						},
+						"last_ping_date_time": schema.StringAttribute{
+							Description: "The time the SSM Agent last pinged Systems Manager.",
+							Computed:    true,
+						},
+						"ping_status": schema.StringAttribute{
+							Description: "Whether the SSM Agent is running, Online, ConnectionLost or Inactive.",
+							Computed:    true,
+						},
+						"platform_name": schema.StringAttribute{
+							Description: "The name of the operating system.",
+							Computed:    true,
+						},
+						"platform_type": schema.StringAttribute{
+							Description: "The operating system platform, Windows, Linux or MacOS.",
+							Computed:    true,
+						},
+						"platform_version": schema.StringAttribute{
+							Description: "The version of the operating system.",
+							Computed:    true,
+						},
+						"resource_type": schema.StringAttribute{
+							Description: "The type of the instance, EC2Instance or ManagedInstance.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"online_count": schema.Int64Attribute{
+				Description: "The number of targeted instances whose SSM Agent is online.",
+				Computed:    true,
+			},
+			"targets": schema.ListNestedAttribute{
+				MarkdownDescription: "The targets to resolve.  Supports `InstanceIds`, `ParameterValues`, `tag:<key>`, `tag-key`, `resource-groups:Name` and `resource-groups:ResourceTypeFilters`.",
+				Required:            true,
+				CustomType:          NewTargetsType(),
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"key": schema.StringAttribute{
+							Description: "The target key.",
+							Required:    true,
+						},
+						"values": schema.ListAttribute{
+							Description: "The values for the target key.",
+							Required:    true,
+							ElementType: types.StringType,
+						},
+					},
+				},
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+func (a *AWSSSMManagedInstancesDataSource) Read(ctx context.Context, request datasource.ReadRequest, response *datasource.ReadResponse) {
+	var data AWSSSMManagedInstancesDataSourceModel
+
+	response.Diagnostics.Append(request.Config.Get(ctx, &data)...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	targets, diags := targetsIn(ctx, data.Targets.ListValue)
+	response.Diagnostics.Append(diags...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	query, err := newInstanceTargetQuery(targets)
+	if err != nil {
+		response.Diagnostics.AddAttributeError(path.Root("targets"), "Invalid targets", err.Error())
+		return
+	}
+
+	if query.resourceGroup != "" {
+		ids, err := findResourceGroupInstanceIDs(ctx, a.Meta.AWSClient.ResourceGroupsClient, query.resourceGroup, query.resourceTypes)
+		if err != nil {
+			response.Diagnostics.AddError("Error reading resource group", err.Error())
+			return
+		}
+
+		query.restrict(ids)
+	}
+
+	instances, err := findManagedInstances(ctx, a.Meta.AWSClient.SSMClient, query)
+	if err != nil {
+		response.Diagnostics.AddError("Error reading managed instances", err.Error())
+		return
+	}
+
+	instanceIds := make([]string, 0, len(instances))
+	result := make([]ManagedInstanceModel, 0, len(instances))
+	var online int64
+
+	for _, instance := range instances {
+		if instance.PingStatus == awstypes.PingStatusOnline {
+			online++
+		}
+
+		instanceIds = append(instanceIds, aws.ToString(instance.InstanceId))
+		result = append(result, ManagedInstanceModel{
+			AgentVersion:     types.StringPointerValue(instance.AgentVersion),
+			ComputerName:     types.StringPointerValue(instance.ComputerName),
+			InstanceId:       types.StringPointerValue(instance.InstanceId),
+			IpAddress:        types.StringPointerValue(instance.IPAddress),
+			IsLatestVersion:  types.BoolPointerValue(instance.IsLatestVersion),
+			LastPingDateTime: timeOut(instance.LastPingDateTime),
+			PingStatus:       types.StringValue(string(instance.PingStatus)),
+			PlatformName:     types.StringPointerValue(instance.PlatformName),
+			PlatformType:     types.StringValue(string(instance.PlatformType)),
+			PlatformVersion:  types.StringPointerValue(instance.PlatformVersion),
+			ResourceType:     types.StringValue(string(instance.ResourceType)),
+		})
+	}
+
+	data.InstanceIds, diags = types.ListValueFrom(ctx, types.StringType, instanceIds)
+	response.Diagnostics.Append(diags...)
+
+	data.Instances, diags = types.ListValueFrom(ctx, managedInstanceObjectType, result)
+	response.Diagnostics.Append(diags...)
+
+	data.OnlineCount = types.Int64Value(online)
+
+	response.Diagnostics.Append(response.State.Set(ctx, &data)...)
+}
+
+// newInstanceTargetQuery translates targets into a managed instance query.  Resource
+// groups still have to be resolved to instance IDs.
+func newInstanceTargetQuery(targets []awstypes.Target) (*instanceTargetQuery, error) {
+	query := &instanceTargetQuery{}
+
+	for _, target := range targets {
+		key := aws.ToString(target.Key)
+
+		switch {
+		case key == targetKeyInstanceIds || key == targetKeyParameterValues:
+			// A single * targets every managed instance.
+			if slices.Equal(target.Values, []string{"*"}) {
+				continue
+			}
+
+			query.restrict(target.Values)
+		case key == targetKeyTagKey:
+			query.filters = append(query.filters, awstypes.InstanceInformationStringFilter{
+				Key:    aws.String(key),
+				Values: target.Values,
+			})
+		case strings.HasPrefix(key, targetKeyTagPrefix) && len(key) > len(targetKeyTagPrefix):
+			query.filters = append(query.filters, awstypes.InstanceInformationStringFilter{
+				Key:    aws.String(key),
+				Values: target.Values,
+			})
+		case key == targetKeyResourceGroupName:
+			if len(target.Values) != 1 {
+				return nil, fmt.Errorf("%s takes exactly one resource group name", key)
+			}
+
+			query.resourceGroup = target.Values[0]
+		case key == targetKeyResourceGroupTypes:
+			query.resourceTypes = append(query.resourceTypes, target.Values...)
+		default:
+			return nil, fmt.Errorf("target key %q cannot be resolved to managed instances", key)
+		}
+	}
+
+	if len(query.resourceTypes) > 0 && query.resourceGroup == "" {
+		return nil, fmt.Errorf("%s requires %s", targetKeyResourceGroupTypes, targetKeyResourceGroupName)
+	}
+
+	return query, nil
+}
+
+// restrict limits the query to the given instance IDs, on top of any earlier restriction.
+func (q *instanceTargetQuery) restrict(instanceIds []string) {
+	if !q.restricted {
+		q.instanceIds = slices.Clone(instanceIds)
+		q.restricted = true
+		return
+	}
+
+	q.instanceIds = slices.DeleteFunc(q.instanceIds, func(id string) bool {
+		return !slices.Contains(instanceIds, id)
+	})
+}
+
+func findManagedInstances(ctx context.Context, conn *ssm.Client, query *instanceTargetQuery) ([]awstypes.InstanceInformation, error) {
+	if !query.restricted {
+		return describeInstanceInformation(ctx, conn, query.filters)
+	}
+
+	var instances []awstypes.InstanceInformation
+
+	for ids := range slices.Chunk(query.instanceIds, instanceIdsFilterMaxValues) {
+		filters := append(slices.Clone(query.filters), awstypes.InstanceInformationStringFilter{
+			Key:    aws.String(string(awstypes.InstanceInformationFilterKeyInstanceIds)),
+			Values: ids,
+		})
+
+		page, err := describeInstanceInformation(ctx, conn, filters)
+		if err != nil {
+			return nil, err
+		}
+
+		instances = append(instances, page...)
+	}
+
+	return instances, nil
+}
+
+func describeInstanceInformation(ctx context.Context, conn *ssm.Client, filters []awstypes.InstanceInformationStringFilter) ([]awstypes.InstanceInformation, error) {
+	input := &ssm.DescribeInstanceInformationInput{
+		Filters: filters,
+	}
+
+	var instances []awstypes.InstanceInformation
+
+	pages := ssm.NewDescribeInstanceInformationPaginator(conn, input)
+	for pages.HasMorePages() {
+		page, err := pages.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		instances = append(instances, page.InstanceInformationList...)
+	}
+
+	return instances, nil
+}
+
+// findResourceGroupInstanceIDs returns the EC2 and managed instances in a resource group.
+func findResourceGroupInstanceIDs(ctx context.Context, conn *resourcegroups.Client, group string, resourceTypes []string) ([]string, error) {
+	if len(resourceTypes) == 0 {
+		resourceTypes = []string{"AWS::EC2::Instance", "AWS::SSM::ManagedInstance"}
+	}
+
+	input := &resourcegroups.ListGroupResourcesInput{
+		Group: aws.String(group),
+		Filters: []rgtypes.ResourceFilter{
+			{
+				Name:   rgtypes.ResourceFilterNameResourceType,
+				Values: resourceTypes,
+			},
+		},
+	}
+
+	var ids []string
+
+	pages := resourcegroups.NewListGroupResourcesPaginator(conn, input)
+	for pages.HasMorePages() {
+		page, err := pages.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range page.Resources {
+			if item.Identifier == nil {
+				continue
+			}
+
+			if id, ok := instanceIDFromARN(aws.ToString(item.Identifier.ResourceArn)); ok {
+				ids = append(ids, id)
+			}
+		}
+	}
+
+	return ids, nil
+}
+
+// instanceIDFromARN returns the instance ID of an EC2 instance or managed instance ARN.
+func instanceIDFromARN(s string) (string, bool) {
+	parsed, err := arn.Parse(s)
+	if err != nil {
+		return "", false
+	}
+
+	for _, prefix := range []string{"instance/", "managed-instance/"} {
+		if id, ok := strings.CutPrefix(parsed.Resource, prefix); ok && id != "" {
+			return id, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,177 @@
+package provider
+
+import (
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awstypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"regexp"
+	"slices"
+	"testing"
+)
+
+func TestAccSSMManagedInstancesDataSource_basic(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	dataSourceName := "data.automation_aws_ssm_managed_instances.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccManagedInstancesDataSourceConfig_basic(rName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "instances.#", "0"),
+					resource.TestCheckResourceAttr(dataSourceName, "instance_ids.#", "0"),
+					resource.TestCheckResourceAttr(dataSourceName, "online_count", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSSMManagedInstancesDataSource_unsupportedKey(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "automation_aws_ssm_managed_instances" "test" {
+  targets = [{
+    key    = "AWS::EC2::Instance"
+    values = ["*"]
+  }]
+}
+`,
+				ExpectError: regexp.MustCompile(`cannot be resolved to managed instances`),
+			},
+		},
+	})
+}
+
+func TestNewInstanceTargetQuery(t *testing.T) {
+	testCases := map[string]struct {
+		targets       []awstypes.Target
+		filterKeys    []string
+		instanceIds   []string
+		restricted    bool
+		resourceGroup string
+		err           bool
+	}{
+		"all instances": {
+			targets: []awstypes.Target{
+				{Key: aws.String("InstanceIds"), Values: []string{"*"}},
+			},
+		},
+		"tags": {
+			targets: []awstypes.Target{
+				{Key: aws.String("tag:Name"), Values: []string{"web"}},
+				{Key: aws.String("tag-key"), Values: []string{"Environment"}},
+			},
+			filterKeys: []string{"tag:Name", "tag-key"},
+		},
+		"instance ids and parameter values": {
+			targets: []awstypes.Target{
+				{Key: aws.String("InstanceIds"), Values: []string{"i-1", "i-2"}},
+				{Key: aws.String("ParameterValues"), Values: []string{"i-2", "i-3"}},
+			},
+			instanceIds: []string{"i-2"},
+			restricted:  true,
+		},
+		"resource group": {
+			targets: []awstypes.Target{
+				{Key: aws.String("resource-groups:Name"), Values: []string{"web"}},
+				{Key: aws.String("resource-groups:ResourceTypeFilters"), Values: []string{"AWS::EC2::Instance"}},
+			},
+			resourceGroup: "web",
+		},
+		"resource group names": {
+			targets: []awstypes.Target{
+				{Key: aws.String("resource-groups:Name"), Values: []string{"web", "db"}},
+			},
+			err: true,
+		},
+		"resource types without group": {
+			targets: []awstypes.Target{
+				{Key: aws.String("resource-groups:ResourceTypeFilters"), Values: []string{"AWS::EC2::Instance"}},
+			},
+			err: true,
+		},
+		"unsupported key": {
+			targets: []awstypes.Target{
+				{Key: aws.String("tag:"), Values: []string{"web"}},
+			},
+			err: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			query, err := newInstanceTargetQuery(testCase.targets)
+
+			if testCase.err {
+				if err == nil {
+					t.Error("expected error")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			var filterKeys []string
+			for _, filter := range query.filters {
+				filterKeys = append(filterKeys, aws.ToString(filter.Key))
+			}
+
+			if !slices.Equal(filterKeys, testCase.filterKeys) {
+				t.Errorf("expected filters %v, got %v", testCase.filterKeys, filterKeys)
+			}
+
+			if query.restricted != testCase.restricted || !slices.Equal(query.instanceIds, testCase.instanceIds) {
+				t.Errorf("expected instance ids %v (%t), got %v (%t)", testCase.instanceIds, testCase.restricted, query.instanceIds, query.restricted)
+			}
+
+			if query.resourceGroup != testCase.resourceGroup {
+				t.Errorf("expected resource group %q, got %q", testCase.resourceGroup, query.resourceGroup)
+			}
+		})
+	}
+}
+
+func TestInstanceIDFromARN(t *testing.T) {
+	testCases := map[string]struct {
+		arn      string
+		expected string
+		ok       bool
+	}{
+		"ec2 instance":     {arn: "arn:aws:ec2:us-east-1:123456789012:instance/i-0123456789abcdef0", expected: "i-0123456789abcdef0", ok: true},
+		"managed instance": {arn: "arn:aws:ssm:us-east-1:123456789012:managed-instance/mi-0123456789abcdef0", expected: "mi-0123456789abcdef0", ok: true},
+		"other resource":   {arn: "arn:aws:s3:::bucket"},
+		"not an arn":       {arn: "i-0123456789abcdef0"},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, ok := instanceIDFromARN(testCase.arn)
+
+			if got != testCase.expected || ok != testCase.ok {
+				t.Errorf("expected %q (%t), got %q (%t)", testCase.expected, testCase.ok, got, ok)
+			}
+		})
+	}
+}
+
+func testAccManagedInstancesDataSourceConfig_basic(rName string) string {
+	return fmt.Sprintf(`
+data "automation_aws_ssm_managed_instances" "test" {
+  targets = [{
+    key    = "tag:Name"
+    values = [%[1]q]
+  }]
+}
+`, rName)
+}
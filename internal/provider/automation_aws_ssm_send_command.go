@@ -0,0 +1,856 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/YakDriver/regexache"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	awstypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/coding-ia/terraform-provider-automation/internal/framework/errs"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int32default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int32planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"strings"
+	"time"
+)
+
+var _ resource.Resource = &AWSSSMSendCommandResource{}
+var _ resource.ResourceWithConfigure = &AWSSSMSendCommandResource{}
+var _ resource.ResourceWithModifyPlan = &AWSSSMSendCommandResource{}
+
+const (
+	commandStatusPending    = "Pending"
+	commandStatusInProgress = "InProgress"
+)
+
+type AWSSSMSendCommandResource struct {
+	Meta Meta
+}
+
+type AWSSSMSendCommandResourceModel struct {
+	CloudWatchOutputConfig       []CloudWatchOutputConfigModel `tfsdk:"cloudwatch_output_config"`
+	CommandId                    types.String                  `tfsdk:"command_id"`
+	Comment                      types.String                  `tfsdk:"comment"`
+	DocumentName                 types.String                  `tfsdk:"document_name"`
+	DocumentVersion              types.String                  `tfsdk:"document_version"`
+	InstanceIds                  types.List                    `tfsdk:"instance_ids"`
+	Invocations                  types.List                    `tfsdk:"invocations"`
+	MaxConcurrency               types.String                  `tfsdk:"max_concurrency"`
+	MaxErrors                    types.String                  `tfsdk:"max_errors"`
+	NotificationConfig           []NotificationConfigModel     `tfsdk:"notification_config"`
+	OutputLocation               []OutputLocationModel         `tfsdk:"output_location"`
+	Parameters                   ParametersValue               `tfsdk:"parameters"`
+	SensitiveParameters          ParametersValue               `tfsdk:"sensitive_parameters"`
+	SensitiveParametersVersion   types.Int32                   `tfsdk:"sensitive_parameters_version"`
+	ServiceRoleArn               types.String                  `tfsdk:"service_role_arn"`
+	Status                       types.String                  `tfsdk:"status"`
+	Targets                      TargetsValue                  `tfsdk:"targets"`
+	TimeoutSeconds               types.Int32                   `tfsdk:"timeout_seconds"`
+	WaitForSuccessTimeoutSeconds types.Int32                   `tfsdk:"wait_for_success_timeout_seconds"`
+}
+
+type CloudWatchOutputConfigModel struct {
+	CloudWatchLogGroupName  types.String `tfsdk:"cloudwatch_log_group_name"`
+	CloudWatchOutputEnabled types.Bool   `tfsdk:"cloudwatch_output_enabled"`
+}
+
+type NotificationConfigModel struct {
+	NotificationArn    types.String `tfsdk:"notification_arn"`
+	NotificationEvents types.List   `tfsdk:"notification_events"`
+	NotificationType   types.String `tfsdk:"notification_type"`
+}
+
+type CommandInvocationModel struct {
+	InstanceId            types.String `tfsdk:"instance_id"`
+	InstanceName          types.String `tfsdk:"instance_name"`
+	ResponseCode          types.Int32  `tfsdk:"response_code"`
+	StandardErrorContent  types.String `tfsdk:"standard_error_content"`
+	StandardErrorUrl      types.String `tfsdk:"standard_error_url"`
+	StandardOutputContent types.String `tfsdk:"standard_output_content"`
+	StandardOutputUrl     types.String `tfsdk:"standard_output_url"`
+	Status                types.String `tfsdk:"status"`
+	StatusDetails         types.String `tfsdk:"status_details"`
+}
+
+var commandInvocationObjectType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"instance_id":             types.StringType,
+		"instance_name":           types.StringType,
+		"response_code":           types.Int32Type,
+		"standard_error_content":  types.StringType,
+		"standard_error_url":      types.StringType,
+		"standard_output_content": types.StringType,
+		"standard_output_url":     types.StringType,
+		"status":                  types.StringType,
+		"status_details":          types.StringType,
+	},
+}
+
+func newAWSSSMSendCommandResource() resource.Resource {
+	return &AWSSSMSendCommandResource{}
+}
+
+func (a *AWSSSMSendCommandResource) Configure(_ context.Context, request resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if request.ProviderData == nil {
+		return
+	}
+
+	a.Meta = request.ProviderData.(Meta)
+}
+
+func (a *AWSSSMSendCommandResource) Metadata(_ context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_aws_ssm_send_command"
+}
+
+func (a *AWSSSMSendCommandResource) Schema(_ context.Context, _ resource.SchemaRequest, response *resource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		MarkdownDescription: "Runs an SSM Command document on managed nodes with Run Command and captures the output of every node.",
+		Attributes: map[string]schema.Attribute{
+			"command_id": schema.StringAttribute{
+				Description: "The ID of the command.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"comment": schema.StringAttribute{
+				Description: "User-specified information about the command, such as a brief description of what the command should do.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtMost(100),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"document_name": schema.StringAttribute{
+				Description: "The name or Amazon Resource Name (ARN) of the SSM Command document to run.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"document_version": schema.StringAttribute{
+				Description: "The document version to run.  Can be a specific version, $LATEST or $DEFAULT.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexache.MustCompile(`^([$]LATEST|[$]DEFAULT|^[1-9][0-9]*$)$`), ""),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"instance_ids": schema.ListAttribute{
+				Description: "The IDs of the managed nodes where the command should run.  Use targets to send the command to a large number of managed nodes.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeBetween(1, 50),
+					listvalidator.ExactlyOneOf(path.MatchRoot("targets")),
+				},
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"invocations": schema.ListNestedAttribute{
+				Description: "The invocation of the command on every managed node.  For documents with several steps, the output of the steps is concatenated and response_code is the first non-zero response code.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"instance_id": schema.StringAttribute{
+							Description: "The ID of the managed node.",
+							Computed:    true,
+						},
+						"instance_name": schema.StringAttribute{
+							Description: "The fully qualified host name of the managed node.",
+							Computed:    true,
+						},
+						"response_code": schema.Int32Attribute{
+							Description: "The exit code of the command on the managed node.",
+							Computed:    true,
+						},
+						"standard_error_content": schema.StringAttribute{
+							Description: "The standard error of the command, from the first 2,500 characters of output of every step.",
+							Computed:    true,
+							Sensitive:   true,
+						},
+						"standard_error_url": schema.StringAttribute{
+							Description: "The URL of the complete standard error in S3, when output_location is set.",
+							Computed:    true,
+						},
+						"standard_output_content": schema.StringAttribute{
+							Description: "The standard output of the command, from the first 2,500 characters of output of every step.",
+							Computed:    true,
+							Sensitive:   true,
+						},
+						"standard_output_url": schema.StringAttribute{
+							Description: "The URL of the complete standard output in S3, when output_location is set.",
+							Computed:    true,
+						},
+						"status": schema.StringAttribute{
+							Description: "The status of the invocation.",
+							Computed:    true,
+						},
+						"status_details": schema.StringAttribute{
+							Description: "A detailed status of the invocation.",
+							Computed:    true,
+						},
+					},
+				},
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"max_concurrency": schema.StringAttribute{
+				Description: "The maximum number of managed nodes that are allowed to run the command at the same time.  You can specify a number, for example 10, or a percentage of the target set, for example 10%.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexache.MustCompile(`^([1-9][0-9]*|[1-9][0-9]%|[1-9]%|100%)$`), "must be a valid number (e.g. 10) or percentage including the percent sign (e.g. 10%)"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"max_errors": schema.StringAttribute{
+				Description: "The number of errors that are allowed before the system stops sending the command to additional targets.  You can specify either an absolute number of errors, for example 10, or a percentage of the target set, for example 10%.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexache.MustCompile(`^([1-9][0-9]*|[0]|[1-9][0-9]%|[0-9]%|100%)$`), "must be a valid number (e.g. 10) or percentage including the percent sign (e.g. 10%)"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"parameters": schema.MapAttribute{
+				Description: "The parameters for the runtime configuration of the document.",
+				Optional:    true,
+				CustomType:  NewParametersType(),
+				ElementType: types.ListType{ElemType: types.StringType},
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"sensitive_parameters": sensitiveParametersAttribute(),
+			"sensitive_parameters_version": sensitiveParametersVersionAttribute(
+				int32planmodifier.RequiresReplace(),
+			),
+			"service_role_arn": schema.StringAttribute{
+				Description: "The ARN of the IAM service role Run Command uses to publish notifications to Amazon SNS.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"status": schema.StringAttribute{
+				Description: "The status of the command: Success when the command succeeded on all managed nodes, otherwise the status of the first invocation that did not succeed.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"targets": schema.ListNestedAttribute{
+				Description:  "The targets for the command.  You can target managed nodes by using tags, AWS resource groups or individual managed node IDs.",
+				Optional:     true,
+				CustomType:   NewTargetsType(),
+				NestedObject: targetNestedObject(),
+				Validators: []validator.List{
+					listvalidator.SizeAtMost(5),
+				},
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"timeout_seconds": schema.Int32Attribute{
+				Description: "The time in seconds for the command to start on a managed node before it is no longer run.",
+				Optional:    true,
+				Validators: []validator.Int32{
+					int32validator.Between(30, 2592000),
+				},
+				PlanModifiers: []planmodifier.Int32{
+					int32planmodifier.RequiresReplace(),
+				},
+			},
+			"wait_for_success_timeout_seconds": schema.Int32Attribute{
+				Description: "The time in seconds to wait for the command to finish on all managed nodes.  Set to 0 to not wait, the invocations then hold the status when the command was sent.",
+				Optional:    true,
+				Computed:    true,
+				Default:     int32default.StaticInt32(600),
+				Validators: []validator.Int32{
+					int32validator.AtLeast(0),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"cloudwatch_output_config": schema.ListNestedBlock{
+				Description: "Sends the command output to CloudWatch Logs.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"cloudwatch_log_group_name": schema.StringAttribute{
+							Description: "The name of the CloudWatch Logs log group.  Defaults to /aws/ssm/<document name>.",
+							Optional:    true,
+							Validators: []validator.String{
+								stringvalidator.LengthBetween(1, 512),
+							},
+							PlanModifiers: []planmodifier.String{
+								stringplanmodifier.RequiresReplace(),
+							},
+						},
+						"cloudwatch_output_enabled": schema.BoolAttribute{
+							Description: "Enables CloudWatch output.",
+							Required:    true,
+							PlanModifiers: []planmodifier.Bool{
+								boolplanmodifier.RequiresReplace(),
+							},
+						},
+					},
+				},
+				Validators: []validator.List{
+					listvalidator.SizeAtMost(1),
+				},
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"notification_config": schema.ListNestedBlock{
+				Description: "Amazon SNS notifications about the command status.  Requires service_role_arn.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"notification_arn": schema.StringAttribute{
+							Description: "The ARN of the Amazon SNS topic.",
+							Required:    true,
+						},
+						"notification_events": schema.ListAttribute{
+							Description: "The events to be notified about: All, InProgress, Success, TimedOut, Cancelled or Failed.",
+							Required:    true,
+							ElementType: types.StringType,
+							Validators: []validator.List{
+								listvalidator.SizeAtLeast(1),
+								listvalidator.ValueStringsAre(
+									stringvalidator.OneOf(
+										[]string{
+											string(awstypes.NotificationEventAll),
+											string(awstypes.NotificationEventInProgress),
+											string(awstypes.NotificationEventSuccess),
+											string(awstypes.NotificationEventTimedOut),
+											string(awstypes.NotificationEventCancelled),
+											string(awstypes.NotificationEventFailed),
+										}...,
+									),
+								),
+							},
+						},
+						"notification_type": schema.StringAttribute{
+							Description: "Command to be notified when the status of the command changes, or Invocation to be notified for every managed node.",
+							Required:    true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(
+									[]string{
+										string(awstypes.NotificationTypeCommand),
+										string(awstypes.NotificationTypeInvocation),
+									}...,
+								),
+							},
+						},
+					},
+				},
+				Validators: []validator.List{
+					listvalidator.SizeAtMost(1),
+					listvalidator.AlsoRequires(path.MatchRoot("service_role_arn")),
+				},
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"output_location": schema.ListNestedBlock{
+				Description: "An Amazon Simple Storage Service (Amazon S3) bucket where you want to store the complete output of the command.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"s3_bucket_name": schema.StringAttribute{
+							Description: "The name of the S3 bucket.",
+							Required:    true,
+							Validators: []validator.String{
+								stringvalidator.LengthBetween(3, 63),
+							},
+						},
+						"s3_key_prefix": schema.StringAttribute{
+							Description: "The S3 bucket subfolder.",
+							Optional:    true,
+							Validators: []validator.String{
+								stringvalidator.LengthBetween(0, 500),
+							},
+						},
+						"s3_region": schema.StringAttribute{
+							Description: "The AWS Region of the S3 bucket.",
+							Optional:    true,
+							Validators: []validator.String{
+								stringvalidator.LengthBetween(3, 20),
+							},
+						},
+					},
+				},
+				Validators: []validator.List{
+					listvalidator.SizeAtMost(1),
+				},
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+// ModifyPlan validates the configured parameters against the parameters of the document
+// and the Parameter Store parameters they reference.
+func (a *AWSSSMSendCommandResource) ModifyPlan(ctx context.Context, request resource.ModifyPlanRequest, response *resource.ModifyPlanResponse) {
+	// Nothing to validate when destroying.
+	if request.Plan.Raw.IsNull() {
+		return
+	}
+
+	var config AWSSSMSendCommandResourceModel
+
+	response.Diagnostics.Append(request.Config.Get(ctx, &config)...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	response.Diagnostics.Append(validateSensitiveParameters(config.Parameters, config.SensitiveParameters)...)
+	response.Diagnostics.Append(validateParameterReferences(ctx, a.Meta.AWSClient.SSMClient, config.Parameters, config.SensitiveParameters)...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	document, diags := findPlanDocument(ctx, a.Meta.AWSClient.SSMClient, config.DocumentName, config.DocumentVersion)
+	response.Diagnostics.Append(diags...)
+
+	if document == nil {
+		return
+	}
+
+	response.Diagnostics.Append(validateDocumentParameters(ctx, document, config.DocumentName.ValueString(), config.Parameters, config.SensitiveParameters)...)
+}
+
+func (a *AWSSSMSendCommandResource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
+	var data AWSSSMSendCommandResourceModel
+	var sensitive ParametersValue
+
+	response.Diagnostics.Append(request.Plan.Get(ctx, &data)...)
+	response.Diagnostics.Append(request.Config.GetAttribute(ctx, path.Root("sensitive_parameters"), &sensitive)...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	ssmClient := a.Meta.AWSClient.SSMClient
+	response.Diagnostics.Append(SendCommand(ctx, ssmClient, &data, sensitive)...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	commandId := data.CommandId.ValueString()
+
+	var invocations []awstypes.CommandInvocation
+	var err error
+
+	if timeout := data.WaitForSuccessTimeoutSeconds.ValueInt32(); timeout > 0 {
+		invocations, err = waitCommandInvocations(ctx, ssmClient, commandId, time.Duration(timeout)*time.Second)
+	} else {
+		invocations, err = findCommandInvocations(ctx, ssmClient, commandId)
+	}
+
+	// The command has run even if it failed on some managed nodes, keep its output in state.
+	data.Status = types.StringValue(commandInvocationsStatus(invocations))
+
+	var diags diag.Diagnostics
+	data.Invocations, diags = commandInvocationsOut(ctx, invocations)
+	response.Diagnostics.Append(diags...)
+
+	response.Diagnostics.Append(response.State.Set(ctx, &data)...)
+
+	if err != nil {
+		response.Diagnostics.AddError("Error running command", fmt.Sprintf("waiting for SSM command (%s): %s%s", commandId, err.Error(), failedCommandInvocations(invocations)))
+	}
+}
+
+func (a *AWSSSMSendCommandResource) Read(ctx context.Context, request resource.ReadRequest, response *resource.ReadResponse) {
+	var data AWSSSMSendCommandResourceModel
+
+	response.Diagnostics.Append(request.State.Get(ctx, &data)...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	conn := a.Meta.AWSClient.SSMClient
+	commandId := data.CommandId.ValueString()
+
+	// SSM only keeps commands for 30 days, keep the output of an expired command in
+	// state rather than running it again.
+	command, err := findCommandByID(ctx, conn, commandId)
+	if errs.IsA[*awstypes.InvalidCommandId](err) || (err == nil && command == nil) {
+		response.Diagnostics.Append(response.State.Set(ctx, &data)...)
+		return
+	}
+	if err != nil {
+		response.Diagnostics.AddError("Error reading command", err.Error())
+		return
+	}
+
+	outputRaw, status, err := statusCommandInvocations(ctx, conn, commandId)()
+	if err != nil {
+		response.Diagnostics.AddError("Error reading command invocations", err.Error())
+		return
+	}
+
+	invocations, _ := outputRaw.([]awstypes.CommandInvocation)
+
+	data.Status = types.StringValue(status)
+
+	var diags diag.Diagnostics
+	data.Invocations, diags = commandInvocationsOut(ctx, invocations)
+	response.Diagnostics.Append(diags...)
+
+	response.Diagnostics.Append(response.State.Set(ctx, &data)...)
+}
+
+func (a *AWSSSMSendCommandResource) Update(ctx context.Context, request resource.UpdateRequest, response *resource.UpdateResponse) {
+	var plan AWSSSMSendCommandResourceModel
+
+	response.Diagnostics.Append(request.Plan.Get(ctx, &plan)...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	response.Diagnostics.Append(response.State.Set(ctx, &plan)...)
+}
+
+func (a *AWSSSMSendCommandResource) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse) {
+	var data AWSSSMSendCommandResourceModel
+
+	response.Diagnostics.Append(request.State.Get(ctx, &data)...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	// Commands cannot be deleted, only cancelled while they are still running.
+	command, err := findCommandByID(ctx, a.Meta.AWSClient.SSMClient, data.CommandId.ValueString())
+	if err != nil || command == nil {
+		return
+	}
+
+	if command.Status == awstypes.CommandStatusPending || command.Status == awstypes.CommandStatusInProgress {
+		_, err = a.Meta.AWSClient.SSMClient.CancelCommand(ctx, &ssm.CancelCommandInput{
+			CommandId: command.CommandId,
+		})
+		if err != nil {
+			response.Diagnostics.AddError("Error cancelling command", err.Error())
+		}
+	}
+}
+
+func SendCommand(ctx context.Context, conn *ssm.Client, data *AWSSSMSendCommandResourceModel, sensitive ParametersValue) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	input := &ssm.SendCommandInput{
+		DocumentName: data.DocumentName.ValueStringPointer(),
+	}
+
+	if len(data.CloudWatchOutputConfig) > 0 {
+		input.CloudWatchOutputConfig = &awstypes.CloudWatchOutputConfig{
+			CloudWatchOutputEnabled: data.CloudWatchOutputConfig[0].CloudWatchOutputEnabled.ValueBool(),
+		}
+
+		if !data.CloudWatchOutputConfig[0].CloudWatchLogGroupName.IsNull() {
+			input.CloudWatchOutputConfig.CloudWatchLogGroupName = data.CloudWatchOutputConfig[0].CloudWatchLogGroupName.ValueStringPointer()
+		}
+	}
+
+	if !data.Comment.IsNull() {
+		input.Comment = data.Comment.ValueStringPointer()
+	}
+
+	if !data.DocumentVersion.IsNull() {
+		input.DocumentVersion = data.DocumentVersion.ValueStringPointer()
+	}
+
+	if !data.InstanceIds.IsNull() {
+		diags.Append(data.InstanceIds.ElementsAs(ctx, &input.InstanceIds, false)...)
+	}
+
+	if !data.MaxConcurrency.IsNull() {
+		input.MaxConcurrency = data.MaxConcurrency.ValueStringPointer()
+	}
+
+	if !data.MaxErrors.IsNull() {
+		input.MaxErrors = data.MaxErrors.ValueStringPointer()
+	}
+
+	if len(data.NotificationConfig) > 0 {
+		input.NotificationConfig = &awstypes.NotificationConfig{
+			NotificationArn:  data.NotificationConfig[0].NotificationArn.ValueStringPointer(),
+			NotificationType: awstypes.NotificationType(data.NotificationConfig[0].NotificationType.ValueString()),
+		}
+
+		var events []string
+		diags.Append(data.NotificationConfig[0].NotificationEvents.ElementsAs(ctx, &events, false)...)
+
+		for _, event := range events {
+			input.NotificationConfig.NotificationEvents = append(input.NotificationConfig.NotificationEvents, awstypes.NotificationEvent(event))
+		}
+	}
+
+	if len(data.OutputLocation) > 0 {
+		input.OutputS3BucketName = data.OutputLocation[0].S3BucketName.ValueStringPointer()
+
+		if !data.OutputLocation[0].S3KeyPrefix.IsNull() {
+			input.OutputS3KeyPrefix = data.OutputLocation[0].S3KeyPrefix.ValueStringPointer()
+		}
+
+		if !data.OutputLocation[0].S3Region.IsNull() {
+			input.OutputS3Region = data.OutputLocation[0].S3Region.ValueStringPointer()
+		}
+	}
+
+	if !data.Parameters.IsNull() && !data.Parameters.IsUnknown() {
+		input.Parameters = parametersIn(ctx, data.Parameters.Elements())
+	}
+
+	input.Parameters = withSensitiveParameters(ctx, input.Parameters, sensitive)
+
+	if !data.ServiceRoleArn.IsNull() {
+		input.ServiceRoleArn = data.ServiceRoleArn.ValueStringPointer()
+	}
+
+	targets, d := targetsIn(ctx, data.Targets.ListValue)
+	diags.Append(d...)
+
+	input.Targets = targets
+
+	if !data.TimeoutSeconds.IsNull() {
+		input.TimeoutSeconds = data.TimeoutSeconds.ValueInt32Pointer()
+	}
+
+	if diags.HasError() {
+		return diags
+	}
+
+	output, err := conn.SendCommand(ctx, input)
+	if err != nil {
+		diags.AddError("Error sending command", err.Error())
+		return diags
+	}
+
+	SetFrameworkFromStringPointer(&data.CommandId, output.Command.CommandId)
+
+	return diags
+}
+
+func findCommandByID(ctx context.Context, conn *ssm.Client, id string) (*awstypes.Command, error) {
+	output, err := conn.ListCommands(ctx, &ssm.ListCommandsInput{
+		CommandId: aws.String(id),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(output.Commands) == 0 {
+		return nil, nil
+	}
+
+	return &output.Commands[0], nil
+}
+
+func findCommandInvocations(ctx context.Context, conn *ssm.Client, id string) ([]awstypes.CommandInvocation, error) {
+	input := &ssm.ListCommandInvocationsInput{
+		CommandId: aws.String(id),
+		Details:   true,
+	}
+
+	var invocations []awstypes.CommandInvocation
+
+	paginator := ssm.NewListCommandInvocationsPaginator(conn, input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		invocations = append(invocations, page.CommandInvocations...)
+	}
+
+	return invocations, nil
+}
+
+func waitCommandInvocations(ctx context.Context, conn *ssm.Client, id string, timeout time.Duration) ([]awstypes.CommandInvocation, error) {
+	stateConf := &retry.StateChangeConf{
+		Pending: []string{
+			commandStatusPending,
+			commandStatusInProgress,
+		},
+		Target: []string{
+			string(awstypes.CommandInvocationStatusSuccess),
+		},
+		Refresh: statusCommandInvocations(ctx, conn, id),
+		Timeout: timeout,
+	}
+
+	outputRaw, err := stateConf.WaitForStateContext(ctx)
+
+	if output, ok := outputRaw.([]awstypes.CommandInvocation); ok {
+		return output, err
+	}
+
+	return nil, err
+}
+
+func statusCommandInvocations(ctx context.Context, conn *ssm.Client, id string) retry.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		invocations, err := findCommandInvocations(ctx, conn, id)
+		if err != nil {
+			return nil, "", err
+		}
+
+		// Invocations are only listed once the targets are resolved, a command that
+		// fails before, e.g. because no managed node matches, has none.
+		if len(invocations) == 0 {
+			command, err := findCommandByID(ctx, conn, id)
+			if err != nil {
+				return nil, "", err
+			}
+
+			if command != nil && command.Status != awstypes.CommandStatusPending && command.Status != awstypes.CommandStatusInProgress {
+				return invocations, string(command.Status), nil
+			}
+		}
+
+		return invocations, commandInvocationsStatus(invocations), nil
+	}
+}
+
+// commandInvocationsStatus aggregates the status of all invocations of a command: Pending
+// until there are invocations, InProgress while any invocation is still running, Success
+// when all succeeded, and otherwise the status of the first invocation that did not.
+func commandInvocationsStatus(invocations []awstypes.CommandInvocation) string {
+	if len(invocations) == 0 {
+		return commandStatusPending
+	}
+
+	for _, invocation := range invocations {
+		switch invocation.Status {
+		case awstypes.CommandInvocationStatusPending,
+			awstypes.CommandInvocationStatusInProgress,
+			awstypes.CommandInvocationStatusDelayed,
+			awstypes.CommandInvocationStatusCancelling:
+			return commandStatusInProgress
+		}
+	}
+
+	for _, invocation := range invocations {
+		if invocation.Status != awstypes.CommandInvocationStatusSuccess {
+			return string(invocation.Status)
+		}
+	}
+
+	return string(awstypes.CommandInvocationStatusSuccess)
+}
+
+// pluginOutputErrorSeparator separates standard output from standard error in the
+// output of a plugin.
+const pluginOutputErrorSeparator = "\n----------ERROR-------\n"
+
+// commandInvocationsOut converts the invocations, listed with their details, to the
+// invocations attribute.  The output of every plugin, i.e. document step, is split into
+// standard output and standard error.
+func commandInvocationsOut(ctx context.Context, invocations []awstypes.CommandInvocation) (types.List, diag.Diagnostics) {
+	models := make([]CommandInvocationModel, 0, len(invocations))
+
+	for _, invocation := range invocations {
+		model := CommandInvocationModel{
+			InstanceId:    types.StringPointerValue(invocation.InstanceId),
+			InstanceName:  types.StringPointerValue(invocation.InstanceName),
+			Status:        types.StringValue(string(invocation.Status)),
+			StatusDetails: types.StringPointerValue(invocation.StatusDetails),
+		}
+
+		var stdout, stderr, stdoutUrls, stderrUrls []string
+		var responseCode int32
+
+		for _, plugin := range invocation.CommandPlugins {
+			if responseCode == 0 {
+				responseCode = plugin.ResponseCode
+			}
+
+			stdoutUrls = appendNonEmpty(stdoutUrls, aws.ToString(plugin.StandardOutputUrl))
+			stderrUrls = appendNonEmpty(stderrUrls, aws.ToString(plugin.StandardErrorUrl))
+
+			pluginStdout, pluginStderr := splitPluginOutput(aws.ToString(plugin.Output))
+			stdout = appendNonEmpty(stdout, pluginStdout)
+			stderr = appendNonEmpty(stderr, pluginStderr)
+		}
+
+		model.ResponseCode = types.Int32Value(responseCode)
+		model.StandardOutputContent = types.StringValue(strings.Join(stdout, "\n"))
+		model.StandardErrorContent = types.StringValue(strings.Join(stderr, "\n"))
+		model.StandardOutputUrl = types.StringValue(strings.Join(stdoutUrls, "\n"))
+		model.StandardErrorUrl = types.StringValue(strings.Join(stderrUrls, "\n"))
+
+		models = append(models, model)
+	}
+
+	return types.ListValueFrom(ctx, commandInvocationObjectType, models)
+}
+
+// splitPluginOutput splits the output of a plugin into standard output and standard
+// error.
+func splitPluginOutput(output string) (string, string) {
+	stdout, stderr, _ := strings.Cut(output, pluginOutputErrorSeparator)
+
+	return stdout, stderr
+}
+
+// failedCommandInvocations describes the invocations that did not succeed for use in
+// error messages.
+func failedCommandInvocations(invocations []awstypes.CommandInvocation) string {
+	var failed []string
+
+	for _, invocation := range invocations {
+		if invocation.Status != awstypes.CommandInvocationStatusSuccess {
+			failed = append(failed, fmt.Sprintf("%s: %s", aws.ToString(invocation.InstanceId), aws.ToString(invocation.StatusDetails)))
+		}
+	}
+
+	if len(failed) == 0 {
+		return ""
+	}
+
+	return "\n\nManaged nodes where the command did not succeed:\n  " + strings.Join(failed, "\n  ")
+}
+
+func appendNonEmpty(values []string, value string) []string {
+	if value == "" {
+		return values
+	}
+
+	return append(values, value)
+}
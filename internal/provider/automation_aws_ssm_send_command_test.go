@@ -0,0 +1,218 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/YakDriver/regexache"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	awstypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"testing"
+)
+
+func TestAccSSMSendCommand_basic(t *testing.T) {
+	ctx := context.Background()
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "automation_aws_ssm_send_command.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		ExternalProviders: map[string]resource.ExternalProvider{
+			"aws": {
+				Source:            "hashicorp/aws",
+				VersionConstraint: "5.87.0",
+			},
+			"time": {
+				Source:            "hashicorp/time",
+				VersionConstraint: "0.12.1",
+			},
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSendCommandConfig_basic(rName, `echo hello; echo oops >&2`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckSendCommandExists(ctx, resourceName),
+					resource.TestCheckResourceAttr(resourceName, "status", "Success"),
+					resource.TestCheckResourceAttr(resourceName, "invocations.#", "1"),
+					resource.TestCheckResourceAttrPair(resourceName, "invocations.0.instance_id", "aws_instance.test", "id"),
+					resource.TestCheckResourceAttr(resourceName, "invocations.0.status", "Success"),
+					resource.TestCheckResourceAttr(resourceName, "invocations.0.response_code", "0"),
+					resource.TestCheckResourceAttr(resourceName, "invocations.0.standard_output_content", "hello\n"),
+					resource.TestCheckResourceAttr(resourceName, "invocations.0.standard_error_content", "oops\n"),
+				),
+			},
+			{
+				RefreshState: true,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "status", "Success"),
+					resource.TestCheckResourceAttr(resourceName, "invocations.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "invocations.0.standard_output_content", "hello\n"),
+				),
+			},
+			{
+				Config:      testAccSendCommandConfig_basic(rName, `exit 3`),
+				ExpectError: regexache.MustCompile(`Managed nodes where the command did not succeed`),
+			},
+		},
+	})
+}
+
+func TestCommandInvocationsStatus(t *testing.T) {
+	testCases := map[string]struct {
+		statuses []awstypes.CommandInvocationStatus
+		want     string
+	}{
+		"no invocations": {want: "Pending"},
+		"in progress": {
+			statuses: []awstypes.CommandInvocationStatus{awstypes.CommandInvocationStatusSuccess, awstypes.CommandInvocationStatusDelayed},
+			want:     "InProgress",
+		},
+		"success": {
+			statuses: []awstypes.CommandInvocationStatus{awstypes.CommandInvocationStatusSuccess, awstypes.CommandInvocationStatusSuccess},
+			want:     "Success",
+		},
+		"failed": {
+			statuses: []awstypes.CommandInvocationStatus{awstypes.CommandInvocationStatusSuccess, awstypes.CommandInvocationStatusTimedOut, awstypes.CommandInvocationStatusFailed},
+			want:     "TimedOut",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var invocations []awstypes.CommandInvocation
+
+			for _, status := range testCase.statuses {
+				invocations = append(invocations, awstypes.CommandInvocation{Status: status})
+			}
+
+			if got := commandInvocationsStatus(invocations); got != testCase.want {
+				t.Errorf("expected %s, got %s", testCase.want, got)
+			}
+		})
+	}
+}
+
+func testAccSendCommandConfig_basic(rName, command string) string {
+	return ConfigCompose(configLatestAmazonLinux2HVMEBSAMI(ec2types.ArchitectureValuesX8664), fmt.Sprintf(`
+data "aws_partition" "current" {}
+
+resource "aws_iam_role" "test" {
+  name = %[1]q
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Action    = "sts:AssumeRole"
+      Effect    = "Allow"
+      Principal = { Service = "ec2.${data.aws_partition.current.dns_suffix}" }
+    }]
+  })
+}
+
+resource "aws_iam_role_policy_attachment" "test" {
+  role       = aws_iam_role.test.name
+  policy_arn = "arn:${data.aws_partition.current.partition}:iam::aws:policy/AmazonSSMManagedInstanceCore"
+}
+
+resource "aws_iam_instance_profile" "test" {
+  name = %[1]q
+  role = aws_iam_role.test.name
+}
+
+resource "aws_instance" "test" {
+  ami                  = data.aws_ami.amzn2-ami-minimal-hvm-ebs-x86_64.id
+  instance_type        = "t3.micro"
+  iam_instance_profile = aws_iam_instance_profile.test.name
+
+  tags = {
+    Name = %[1]q
+  }
+
+  depends_on = [aws_iam_role_policy_attachment.test]
+}
+
+# Give the SSM Agent time to register the instance.
+resource "time_sleep" "test" {
+  create_duration = "120s"
+
+  depends_on = [aws_instance.test]
+}
+
+resource "automation_aws_ssm_send_command" "test" {
+  document_name = "AWS-RunShellScript"
+  comment       = %[1]q
+
+  instance_ids = [aws_instance.test.id]
+
+  parameters = {
+    commands = [%[2]q]
+  }
+
+  wait_for_success_timeout_seconds = 300
+
+  depends_on = [time_sleep.test]
+}
+`, rName, command))
+}
+
+func testAccCheckSendCommandExists(ctx context.Context, n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		conn := getProviderMeta(ctx).AWSClient.SSMClient
+
+		command, err := findCommandByID(ctx, conn, rs.Primary.Attributes["command_id"])
+		if err != nil {
+			return err
+		}
+
+		if command == nil {
+			return fmt.Errorf("SSM command %s not found", rs.Primary.Attributes["command_id"])
+		}
+
+		return nil
+	}
+}
+
+func TestSplitPluginOutput(t *testing.T) {
+	testCases := map[string]struct {
+		output     string
+		wantStdout string
+		wantStderr string
+	}{
+		"empty": {},
+		"standard output": {
+			output:     "hello\n",
+			wantStdout: "hello\n",
+		},
+		"standard error": {
+			output:     "\n----------ERROR-------\noops\n",
+			wantStderr: "oops\n",
+		},
+		"both": {
+			output:     "hello\n\n----------ERROR-------\noops\n",
+			wantStdout: "hello\n",
+			wantStderr: "oops\n",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			stdout, stderr := splitPluginOutput(testCase.output)
+
+			if stdout != testCase.wantStdout {
+				t.Errorf("expected standard output %q, got %q", testCase.wantStdout, stdout)
+			}
+
+			if stderr != testCase.wantStderr {
+				t.Errorf("expected standard error %q, got %q", testCase.wantStderr, stderr)
+			}
+		})
+	}
+}
@@ -4,15 +4,17 @@ import (
 	"context"
 	"fmt"
 	"github.com/YakDriver/regexache"
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ssm"
 	awstypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
 	"github.com/coding-ia/terraform-provider-automation/internal/framework/errs"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
-	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int32planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -25,24 +27,38 @@ import (
 
 var _ resource.Resource = &AWSSSMStartAutomationExecutionResource{}
 var _ resource.ResourceWithConfigure = &AWSSSMStartAutomationExecutionResource{}
+var _ resource.ResourceWithUpgradeState = &AWSSSMStartAutomationExecutionResource{}
+var _ resource.ResourceWithModifyPlan = &AWSSSMStartAutomationExecutionResource{}
+
+// startAutomationExecutionStateUpgradeSteps upgrades prior state one schema version at a time.  Append a
+// step for every schema change, the schema version is the number of steps.
+var startAutomationExecutionStateUpgradeSteps = []stateUpgradeStep{
+	upgradeTargetsStateV0,
+	upgradeDocumentVersionSelectorStateV1,
+}
 
 type AWSSSMStartAutomationExecutionResource struct {
 	Meta Meta
 }
 
 type AWSSSMStartAutomationExecutionResourceModel struct {
-	AutomationId                 types.String `tfsdk:"automation_id"`
-	ClientToken                  types.String `tfsdk:"client_token"`
-	DocumentName                 types.String `tfsdk:"document_name"`
-	DocumentVersion              types.String `tfsdk:"document_version"`
-	MaxConcurrency               types.String `tfsdk:"max_concurrency"`
-	MaxErrors                    types.String `tfsdk:"max_errors"`
-	Mode                         types.String `tfsdk:"mode"`
-	Parameters                   types.Map    `tfsdk:"parameters"`
-	Tags                         types.Map    `tfsdk:"tags"`
-	TargetParameterName          types.String `tfsdk:"target_parameter_name"`
-	Targets                      types.List   `tfsdk:"targets"`
-	WaitForSuccessTimeoutSeconds types.Int32  `tfsdk:"wait_for_success_timeout_seconds"`
+	AlarmConfiguration           []AlarmConfigurationModel `tfsdk:"alarm_configuration"`
+	AutomationId                 types.String              `tfsdk:"automation_id"`
+	ClientToken                  types.String              `tfsdk:"client_token"`
+	DocumentName                 types.String              `tfsdk:"document_name"`
+	DocumentVersion              types.String              `tfsdk:"document_version"`
+	DocumentVersionSelector      types.String              `tfsdk:"document_version_selector"`
+	MaxConcurrency               types.String              `tfsdk:"max_concurrency"`
+	MaxErrors                    types.String              `tfsdk:"max_errors"`
+	Mode                         types.String              `tfsdk:"mode"`
+	Parameters                   ParametersValue           `tfsdk:"parameters"`
+	SensitiveParameters          ParametersValue           `tfsdk:"sensitive_parameters"`
+	SensitiveParametersVersion   types.Int32               `tfsdk:"sensitive_parameters_version"`
+	Tags                         types.Map                 `tfsdk:"tags"`
+	TargetParameterName          types.String              `tfsdk:"target_parameter_name"`
+	Targets                      TargetsValue              `tfsdk:"targets"`
+	TriggeredAlarms              types.List                `tfsdk:"triggered_alarms"`
+	WaitForSuccessTimeoutSeconds types.Int32               `tfsdk:"wait_for_success_timeout_seconds"`
 }
 
 func newAWSSSMStartAutomationExecutionResource() resource.Resource {
@@ -63,6 +79,7 @@ func (a *AWSSSMStartAutomationExecutionResource) Metadata(ctx context.Context, r
 
 func (a *AWSSSMStartAutomationExecutionResource) Schema(ctx context.Context, request resource.SchemaRequest, response *resource.SchemaResponse) {
 	response.Schema = schema.Schema{
+		Version:             int64(len(startAutomationExecutionStateUpgradeSteps)),
 		MarkdownDescription: "Start automation of an SSM Document to an instance or EC2 tag.",
 		Attributes: map[string]schema.Attribute{
 			"automation_id": schema.StringAttribute{
@@ -86,17 +103,13 @@ func (a *AWSSSMStartAutomationExecutionResource) Schema(ctx context.Context, req
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
-			"document_version": schema.StringAttribute{
-				Description: "The document version you want to associate with the targets.  Can be a specific version or the default version.",
-				Optional:    true,
-				Computed:    true,
-				Validators: []validator.String{
-					stringvalidator.RegexMatches(regexache.MustCompile(`^([$]LATEST|[$]DEFAULT|^[1-9][0-9]*$)$`), ""),
-				},
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
-			},
+			"document_version": documentVersionAttribute(
+				"The version of the runbook to run.",
+				stringplanmodifier.RequiresReplace(),
+			),
+			"document_version_selector": documentVersionSelectorAttribute(
+				stringplanmodifier.RequiresReplace(),
+			),
 			"max_concurrency": schema.StringAttribute{
 				Description: "The maximum number of targets allowed to run the association at the same time.  You can specify a number, for example 10, or a percentage of the target set, for example 10%.",
 				Optional:    true,
@@ -135,11 +148,16 @@ func (a *AWSSSMStartAutomationExecutionResource) Schema(ctx context.Context, req
 			"parameters": schema.MapAttribute{
 				Description: "The parameters for the runtime configuration of the document.",
 				Optional:    true,
+				CustomType:  NewParametersType(),
 				ElementType: types.ListType{ElemType: types.StringType},
 				PlanModifiers: []planmodifier.Map{
 					mapplanmodifier.RequiresReplace(),
 				},
 			},
+			"sensitive_parameters": sensitiveParametersAttribute(),
+			"sensitive_parameters_version": sensitiveParametersVersionAttribute(
+				int32planmodifier.RequiresReplace(),
+			),
 			"tags": schema.MapAttribute{
 				Optional:    true,
 				ElementType: types.StringType,
@@ -156,15 +174,11 @@ func (a *AWSSSMStartAutomationExecutionResource) Schema(ctx context.Context, req
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
-			"targets": schema.ListAttribute{
-				Description: "The targets for the SSM automation execution.  You can target managed nodes by using tags, AWS resource groups, all managed nodes in an AWS account, or individual managed node IDs.",
-				Optional:    true,
-				ElementType: types.ObjectType{
-					AttrTypes: map[string]attr.Type{
-						"key":    types.StringType,
-						"values": types.ListType{ElemType: types.StringType},
-					},
-				},
+			"targets": schema.ListNestedAttribute{
+				Description:  "The targets for the SSM automation execution.  You can target managed nodes by using tags, AWS resource groups, all managed nodes in an AWS account, or individual managed node IDs.",
+				Optional:     true,
+				CustomType:   NewTargetsType(),
+				NestedObject: targetNestedObject(),
 				Validators: []validator.List{
 					listvalidator.SizeAtMost(5),
 				},
@@ -172,25 +186,75 @@ func (a *AWSSSMStartAutomationExecutionResource) Schema(ctx context.Context, req
 					listplanmodifier.RequiresReplace(),
 				},
 			},
+			"triggered_alarms": schema.ListAttribute{
+				Description: "The CloudWatch alarms that were invoked by the automation.",
+				Computed:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"wait_for_success_timeout_seconds": schema.Int32Attribute{
 				Optional: true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"alarm_configuration": alarmConfigurationBlock(listplanmodifier.RequiresReplace()),
+		},
+	}
+
+}
+
+func (a *AWSSSMStartAutomationExecutionResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return stateUpgraders(startAutomationExecutionStateUpgradeSteps...)
+}
+
+// ModifyPlan validates the configured parameters against the parameters of the document
+// and the Parameter Store parameters they reference.
+func (a *AWSSSMStartAutomationExecutionResource) ModifyPlan(ctx context.Context, request resource.ModifyPlanRequest, response *resource.ModifyPlanResponse) {
+	// Nothing to validate when destroying.
+	if request.Plan.Raw.IsNull() {
+		return
+	}
+
+	var config AWSSSMStartAutomationExecutionResourceModel
+
+	response.Diagnostics.Append(request.Config.Get(ctx, &config)...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	response.Diagnostics.Append(validateSensitiveParameters(config.Parameters, config.SensitiveParameters)...)
+	response.Diagnostics.Append(validateParameterReferences(ctx, a.Meta.AWSClient.SSMClient, config.Parameters, config.SensitiveParameters)...)
+
+	if response.Diagnostics.HasError() {
+		return
 	}
 
+	document, diags := findPlanDocument(ctx, a.Meta.AWSClient.SSMClient, config.DocumentName, planDocumentVersion(config.DocumentVersion, config.DocumentVersionSelector))
+	response.Diagnostics.Append(diags...)
+
+	if document == nil {
+		return
+	}
+
+	response.Diagnostics.Append(validateDocumentParameters(ctx, document, config.DocumentName.ValueString(), config.Parameters, config.SensitiveParameters, config.TargetParameterName)...)
 }
 
 func (a *AWSSSMStartAutomationExecutionResource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
 	var data AWSSSMStartAutomationExecutionResourceModel
+	var sensitive ParametersValue
 
 	response.Diagnostics.Append(request.Plan.Get(ctx, &data)...)
+	response.Diagnostics.Append(request.Config.GetAttribute(ctx, path.Root("sensitive_parameters"), &sensitive)...)
 
 	if response.Diagnostics.HasError() {
 		return
 	}
 
 	ssmClient := a.Meta.AWSClient.SSMClient
-	err := StartAutomationExecution(ctx, ssmClient, &data)
+	err := StartAutomationExecution(ctx, ssmClient, &data, sensitive)
 	if err != nil {
 		response.Diagnostics.AddError("Error starting automation execution", err.Error())
 		return
@@ -199,7 +263,11 @@ func (a *AWSSSMStartAutomationExecutionResource) Create(ctx context.Context, req
 	if !data.WaitForSuccessTimeoutSeconds.IsNull() &&
 		!data.WaitForSuccessTimeoutSeconds.IsUnknown() {
 		timeout := time.Duration(data.WaitForSuccessTimeoutSeconds.ValueInt32()) * time.Second
-		if _, err := waitStartAutomation(ctx, ssmClient, data.AutomationId.ValueStringPointer(), timeout, response.Diagnostics); err != nil {
+		ae, err := waitStartAutomation(ctx, ssmClient, data.AutomationId.ValueStringPointer(), timeout, &response.Diagnostics)
+		if ae != nil {
+			data.TriggeredAlarms = triggeredAlarmsOut(ctx, ae.TriggeredAlarms)
+		}
+		if err != nil {
 			response.Diagnostics.AddError("Error executing SSM automation", fmt.Sprintf("waiting for SSM execution (%s): %s", data.AutomationId.String(), err.Error()))
 			return
 		}
@@ -259,18 +327,22 @@ func (a *AWSSSMStartAutomationExecutionResource) Delete(ctx context.Context, req
 	response.Diagnostics.Append(response.State.Set(ctx, &data)...)
 }
 
-func StartAutomationExecution(ctx context.Context, conn *ssm.Client, data *AWSSSMStartAutomationExecutionResourceModel) error {
+func StartAutomationExecution(ctx context.Context, conn *ssm.Client, data *AWSSSMStartAutomationExecutionResourceModel, sensitive ParametersValue) error {
+	alarmConfiguration, diags := alarmConfigurationIn(ctx, data.AlarmConfiguration)
+	for _, d := range diags.Errors() {
+		return fmt.Errorf("reading alarm configuration: %s: %s", d.Summary(), d.Detail())
+	}
+
 	input := &ssm.StartAutomationExecutionInput{
-		DocumentName: data.DocumentName.ValueStringPointer(),
+		AlarmConfiguration: alarmConfiguration,
+		DocumentName:       data.DocumentName.ValueStringPointer(),
 	}
 
 	if !data.ClientToken.IsNull() {
 		input.ClientToken = data.ClientToken.ValueStringPointer()
 	}
 
-	if data.DocumentVersion.ValueString() != "" {
-		input.DocumentVersion = data.DocumentVersion.ValueStringPointer()
-	}
+	input.DocumentVersion = documentVersionIn(data.DocumentVersion, data.DocumentVersionSelector)
 
 	if !data.MaxConcurrency.IsNull() {
 		input.MaxConcurrency = data.MaxConcurrency.ValueStringPointer()
@@ -288,6 +360,8 @@ func StartAutomationExecution(ctx context.Context, conn *ssm.Client, data *AWSSS
 		input.Parameters = parametersIn(ctx, data.Parameters.Elements())
 	}
 
+	input.Parameters = withSensitiveParameters(ctx, input.Parameters, sensitive)
+
 	if !data.Tags.IsNull() && !data.Parameters.IsUnknown() {
 		input.Tags = tagsIn(data.Tags.Elements())
 	}
@@ -296,8 +370,17 @@ func StartAutomationExecution(ctx context.Context, conn *ssm.Client, data *AWSSS
 		input.TargetParameterName = data.TargetParameterName.ValueStringPointer()
 	}
 
-	if !data.Targets.IsNull() && !data.Targets.IsUnknown() {
-		input.Targets = targetsIn(data.Targets)
+	targets, diags := targetsIn(ctx, data.Targets.ListValue)
+	for _, d := range diags.Errors() {
+		return fmt.Errorf("reading targets: %s: %s", d.Summary(), d.Detail())
+	}
+
+	input.Targets = targets
+
+	// Resolve the selector before starting the execution, failing afterwards would lose it.
+	documentVersion, documentVersionSelector, err := documentVersionOut(ctx, conn, data.DocumentName.ValueString(), aws.ToString(input.DocumentVersion), data.DocumentVersion)
+	if err != nil {
+		return err
 	}
 
 	output, err := conn.StartAutomationExecution(ctx, input)
@@ -312,8 +395,16 @@ func StartAutomationExecution(ctx context.Context, conn *ssm.Client, data *AWSSS
 
 	SetFrameworkFromStringPointer(&data.AutomationId, output.AutomationExecutionId)
 
+	data.DocumentVersion, data.DocumentVersionSelector = documentVersion, documentVersionSelector
+
+	data.TriggeredAlarms = triggeredAlarmsOut(ctx, nil)
+
 	if ae != nil {
-		SetFrameworkFromStringPointer(&data.DocumentVersion, ae.DocumentVersion)
+		// The version the execution runs is more accurate than resolving the selector again.
+		if version := aws.ToString(ae.DocumentVersion); version != "" && !isDocumentVersionSelector(version) && !isDocumentVersionSelector(data.DocumentVersion.ValueString()) {
+			data.DocumentVersion = types.StringValue(version)
+		}
+		data.TriggeredAlarms = triggeredAlarmsOut(ctx, ae.TriggeredAlarms)
 	}
 
 	return nil
@@ -349,7 +440,7 @@ func StopAutomationExecution(ctx context.Context, conn *ssm.Client, id *string)
 	return nil
 }
 
-func waitStartAutomation(ctx context.Context, conn *ssm.Client, id *string, timeout time.Duration, diag diag.Diagnostics) (*awstypes.AutomationExecution, error) {
+func waitStartAutomation(ctx context.Context, conn *ssm.Client, id *string, timeout time.Duration, diags *diag.Diagnostics) (*awstypes.AutomationExecution, error) {
 	stateConf := &retry.StateChangeConf{
 		Pending: []string{
 			string(awstypes.AutomationExecutionStatusPending),
@@ -366,7 +457,11 @@ func waitStartAutomation(ctx context.Context, conn *ssm.Client, id *string, time
 
 	if output, ok := outputRaw.(*awstypes.AutomationExecution); ok {
 		if status := string(output.AutomationExecutionStatus); status == string(awstypes.AutomationExecutionStatusFailed) {
-			diag.AddError("Association error", string(output.AutomationExecutionStatus))
+			diags.AddError("Association error", string(output.AutomationExecutionStatus))
+		}
+
+		if err != nil {
+			appendTriggeredAlarmsDiagnostic(diags, output.TriggeredAlarms)
 		}
 
 		return output, err
@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/coding-ia/terraform-provider-automation/internal/ssmdoc"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var _ basetypes.StringTypable = DocumentContentType{}
+var _ basetypes.StringValuableWithSemanticEquals = DocumentContentValue{}
+
+// DocumentContentType is the string type for SSM document content.  SSM may return the
+// content re-serialized, so contents holding the same JSON or YAML data are semantically
+// equal regardless of layout and key order.
+type DocumentContentType struct {
+	basetypes.StringType
+}
+
+func NewDocumentContentType() DocumentContentType {
+	return DocumentContentType{}
+}
+
+func (t DocumentContentType) Equal(o attr.Type) bool {
+	other, ok := o.(DocumentContentType)
+	if !ok {
+		return false
+	}
+
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t DocumentContentType) String() string {
+	return "DocumentContentType"
+}
+
+func (t DocumentContentType) ValueFromString(_ context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return DocumentContentValue{
+		StringValue: in,
+	}, nil
+}
+
+func (t DocumentContentType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	return DocumentContentValue{
+		StringValue: stringValue,
+	}, nil
+}
+
+func (t DocumentContentType) ValueType(_ context.Context) attr.Value {
+	return DocumentContentValue{}
+}
+
+type DocumentContentValue struct {
+	basetypes.StringValue
+}
+
+func NewDocumentContentValue(value string) DocumentContentValue {
+	return DocumentContentValue{
+		StringValue: types.StringValue(value),
+	}
+}
+
+func (v DocumentContentValue) Equal(o attr.Value) bool {
+	other, ok := o.(DocumentContentValue)
+	if !ok {
+		return false
+	}
+
+	return v.StringValue.Equal(other.StringValue)
+}
+
+func (v DocumentContentValue) Type(_ context.Context) attr.Type {
+	return NewDocumentContentType()
+}
+
+func (v DocumentContentValue) StringSemanticEquals(_ context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(DocumentContentValue)
+	if !ok {
+		diags.AddError("Semantic Equality Check Error", fmt.Sprintf("Expected value type %T, got %T", v, newValuable))
+		return false, diags
+	}
+
+	return ssmdoc.Equal(v.ValueString(), newValue.ValueString()), diags
+}
@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"context"
+	"testing"
+)
+
+func TestDocumentContentValue_StringSemanticEquals(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := map[string]struct {
+		prior, proposed string
+		want            bool
+	}{
+		"identical": {
+			prior:    `{"schemaVersion":"2.2"}`,
+			proposed: `{"schemaVersion":"2.2"}`,
+			want:     true,
+		},
+		"reformatted": {
+			prior:    `{"schemaVersion":"2.2","description":"Test"}`,
+			proposed: "{\n  \"description\": \"Test\",\n  \"schemaVersion\": \"2.2\"\n}\n",
+			want:     true,
+		},
+		"yaml": {
+			prior:    "schemaVersion: '0.3'\nmainSteps:\n  - name: sleep\n    action: aws:sleep\n",
+			proposed: "mainSteps:\n- action: aws:sleep\n  name: sleep\nschemaVersion: \"0.3\"\n",
+			want:     true,
+		},
+		"changed": {
+			prior:    `{"schemaVersion":"2.2","description":"Test"}`,
+			proposed: `{"schemaVersion":"2.2","description":"Changed"}`,
+		},
+		"text": {
+			prior:    "BEGIN:VCALENDAR\nEND:VCALENDAR",
+			proposed: "BEGIN:VCALENDAR\r\nEND:VCALENDAR",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, diags := NewDocumentContentValue(testCase.prior).StringSemanticEquals(ctx, NewDocumentContentValue(testCase.proposed))
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+
+			if got != testCase.want {
+				t.Errorf("expected %t, got %t", testCase.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/YakDriver/regexache"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Document version selectors that SSM resolves when the document is run.
+const (
+	documentVersionDefault = "$DEFAULT"
+	documentVersionLatest  = "$LATEST"
+)
+
+// documentVersionAttribute is a pinned, numeric document version.  When a selector is
+// used instead, it holds the version the selector currently resolves to.  Selectors set
+// in the attribute itself are deprecated and kept as they are.
+func documentVersionAttribute(description string, planModifiers ...planmodifier.String) schema.StringAttribute {
+	return schema.StringAttribute{
+		MarkdownDescription: description + "  Setting `$DEFAULT` or `$LATEST` is deprecated, use `document_version_selector` instead.",
+		Optional:            true,
+		Computed:            true,
+		Validators: []validator.String{
+			stringvalidator.Any(
+				stringvalidator.RegexMatches(regexache.MustCompile(`^[1-9][0-9]*$`), "must be a document version number"),
+				stringvalidator.OneOf(documentVersionDefault, documentVersionLatest),
+			),
+			stringvalidator.ConflictsWith(path.MatchRoot("document_version_selector")),
+			deprecatedDocumentVersionSelectorValidator{},
+		},
+		PlanModifiers: planModifiers,
+	}
+}
+
+var _ validator.String = deprecatedDocumentVersionSelectorValidator{}
+
+// deprecatedDocumentVersionSelectorValidator warns about $DEFAULT and $LATEST in
+// document_version.
+type deprecatedDocumentVersionSelectorValidator struct{}
+
+func (v deprecatedDocumentVersionSelectorValidator) Description(ctx context.Context) string {
+	return v.MarkdownDescription(ctx)
+}
+
+func (v deprecatedDocumentVersionSelectorValidator) MarkdownDescription(_ context.Context) string {
+	return "value should not be $DEFAULT or $LATEST"
+}
+
+func (v deprecatedDocumentVersionSelectorValidator) ValidateString(_ context.Context, request validator.StringRequest, response *validator.StringResponse) {
+	if request.ConfigValue.IsNull() || request.ConfigValue.IsUnknown() || !isDocumentVersionSelector(request.ConfigValue.ValueString()) {
+		return
+	}
+
+	response.Diagnostics.AddAttributeWarning(
+		request.Path,
+		"Deprecated Document Version",
+		fmt.Sprintf("Setting document_version to %[1]s is deprecated, set document_version_selector to %[1]s instead.  document_version then shows the version %[1]s resolves to.", request.ConfigValue.ValueString()),
+	)
+}
+
+func documentVersionSelectorAttribute(planModifiers ...planmodifier.String) schema.StringAttribute {
+	return schema.StringAttribute{
+		MarkdownDescription: "Follow the default (`$DEFAULT`) or latest (`$LATEST`) version of the document instead of pinning `document_version`.  `document_version` shows the version it resolves to.",
+		Optional:            true,
+		Computed:            true,
+		Validators: []validator.String{
+			stringvalidator.OneOf(documentVersionDefault, documentVersionLatest),
+		},
+		PlanModifiers: planModifiers,
+	}
+}
+
+// documentVersionIn returns the document version to send to AWS, the pinned version
+// or the selector.  Unconfigured versions are computed, so they are unknown or null.
+func documentVersionIn(version, selector types.String) *string {
+	if !version.IsUnknown() && version.ValueString() != "" {
+		return version.ValueStringPointer()
+	}
+
+	if !selector.IsUnknown() && !selector.IsNull() {
+		return selector.ValueStringPointer()
+	}
+
+	return nil
+}
+
+// isDocumentVersionSelector reports whether a document version is $DEFAULT or $LATEST.
+func isDocumentVersionSelector(version string) bool {
+	return version == documentVersionDefault || version == documentVersionLatest
+}
+
+// planDocumentVersion returns the configured version or selector for reading the
+// document at plan time.
+func planDocumentVersion(version, selector types.String) types.String {
+	if version.IsNull() {
+		return selector
+	}
+
+	return version
+}
+
+// documentVersionOut splits a document version returned by AWS into the numeric version
+// and the selector it was resolved from, resolving $DEFAULT and $LATEST.  A deprecated
+// selector in the planned or prior document_version is kept as it is, as is a known
+// version when the selector cannot be resolved.
+func documentVersionOut(ctx context.Context, conn *ssm.Client, name, version string, pinned types.String) (types.String, types.String, error) {
+	if isDocumentVersionSelector(pinned.ValueString()) {
+		return pinned, pinned, nil
+	}
+
+	if version != "" && !isDocumentVersionSelector(version) {
+		return types.StringValue(version), types.StringNull(), nil
+	}
+
+	if version == "" {
+		version = documentVersionDefault
+	}
+
+	document, err := findDocumentByName(ctx, conn, name, "")
+	if err != nil {
+		return priorDocumentVersion(pinned), types.StringValue(version), fmt.Errorf("resolving %s version of document %s: %w", version, name, err)
+	}
+
+	if document == nil {
+		return priorDocumentVersion(pinned), types.StringValue(version), fmt.Errorf("resolving %s version of document %s: document not found", version, name)
+	}
+
+	resolved := document.DefaultVersion
+	if version == documentVersionLatest {
+		resolved = document.LatestVersion
+	}
+
+	return types.StringValue(aws.ToString(resolved)), types.StringValue(version), nil
+}
+
+// priorDocumentVersion returns the planned or prior document_version to keep when a
+// selector cannot be resolved, null when it is not known yet.
+func priorDocumentVersion(version types.String) types.String {
+	if version.IsUnknown() {
+		return types.StringNull()
+	}
+
+	return version
+}
+
+// documentVersionWarning reports a selector that could not be resolved.  document_version
+// keeps its prior value, or is null for new associations, until the next refresh.
+func documentVersionWarning(err error) diag.Diagnostic {
+	return diag.NewWarningDiagnostic("Unable to resolve document version", err.Error()+"  document_version is read again on the next refresh.")
+}
+
+// upgradeDocumentVersionSelectorStateV1 copies $DEFAULT and $LATEST from
+// document_version into document_version_selector.  document_version keeps the selector,
+// as prior state cannot tell whether it was configured.
+func upgradeDocumentVersionSelectorStateV1(state map[string]any) error {
+	version, _ := state["document_version"].(string)
+
+	if isDocumentVersionSelector(version) {
+		state["document_version_selector"] = version
+	}
+
+	return nil
+}
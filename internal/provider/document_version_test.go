@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"context"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"testing"
+)
+
+func TestDocumentVersionIn(t *testing.T) {
+	testCases := map[string]struct {
+		version, selector types.String
+		want              *string
+	}{
+		"pinned": {
+			version:  types.StringValue("3"),
+			selector: types.StringNull(),
+			want:     aws.String("3"),
+		},
+		"selector": {
+			version:  types.StringUnknown(),
+			selector: types.StringValue(documentVersionLatest),
+			want:     aws.String(documentVersionLatest),
+		},
+		"pinned after selector": {
+			version:  types.StringValue("3"),
+			selector: types.StringUnknown(),
+			want:     aws.String("3"),
+		},
+		"unconfigured": {
+			version:  types.StringUnknown(),
+			selector: types.StringUnknown(),
+		},
+		"empty": {
+			version:  types.StringValue(""),
+			selector: types.StringNull(),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := documentVersionIn(testCase.version, testCase.selector)
+
+			if aws.ToString(got) != aws.ToString(testCase.want) || (got == nil) != (testCase.want == nil) {
+				t.Errorf("expected %v, got %v", aws.ToString(testCase.want), aws.ToString(got))
+			}
+		})
+	}
+}
+
+func TestDocumentVersionOut(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := map[string]struct {
+		version               string
+		pinned                types.String
+		wantVersion, selector types.String
+	}{
+		"numeric": {
+			version:     "3",
+			pinned:      types.StringUnknown(),
+			wantVersion: types.StringValue("3"),
+			selector:    types.StringNull(),
+		},
+		"deprecated selector": {
+			version:     documentVersionDefault,
+			pinned:      types.StringValue(documentVersionDefault),
+			wantVersion: types.StringValue(documentVersionDefault),
+			selector:    types.StringValue(documentVersionDefault),
+		},
+		"deprecated selector in state": {
+			version:     documentVersionLatest,
+			pinned:      types.StringValue(documentVersionLatest),
+			wantVersion: types.StringValue(documentVersionLatest),
+			selector:    types.StringValue(documentVersionLatest),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			version, selector, err := documentVersionOut(ctx, nil, "test", testCase.version, testCase.pinned)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !version.Equal(testCase.wantVersion) || !selector.Equal(testCase.selector) {
+				t.Errorf("expected %s %s, got %s %s", testCase.wantVersion, testCase.selector, version, selector)
+			}
+		})
+	}
+}
+
+func TestPriorDocumentVersion(t *testing.T) {
+	testCases := map[string]struct {
+		version types.String
+		want    types.String
+	}{
+		"known": {
+			version: types.StringValue("2"),
+			want:    types.StringValue("2"),
+		},
+		"unknown": {
+			version: types.StringUnknown(),
+			want:    types.StringNull(),
+		},
+		"null": {
+			version: types.StringNull(),
+			want:    types.StringNull(),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := priorDocumentVersion(testCase.version); !got.Equal(testCase.want) {
+				t.Errorf("expected %s, got %s", testCase.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,315 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	awstypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/coding-ia/terraform-provider-automation/internal/framework/errs"
+	"github.com/coding-ia/terraform-provider-automation/internal/ssmdoc"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"maps"
+	"slices"
+)
+
+// findPlanDocument returns the content of the document a resource is planned with.  The
+// document is nil while its name or version is unknown, or when it does not exist yet, as
+// it may be created in the same apply.
+func findPlanDocument(ctx context.Context, conn *ssm.Client, name, version types.String) (*ssmdoc.Document, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if conn == nil || name.IsUnknown() || version.IsUnknown() {
+		return nil, diags
+	}
+
+	document, err := findDocumentContent(ctx, conn, name.ValueString(), version.ValueString())
+	if errs.IsA[*awstypes.InvalidDocument](err) || errs.IsA[*awstypes.InvalidDocumentVersion](err) {
+		return nil, diags
+	}
+
+	if err != nil {
+		diags.AddWarning(
+			"Unable to read document parameters",
+			fmt.Sprintf("Parameters of document %s are validated when applied instead: %s", name.ValueString(), err),
+		)
+		return nil, diags
+	}
+
+	return document, diags
+}
+
+// validateDocumentParameters checks configured parameters against the parameter
+// definitions of the document, reporting errors at the path of each parameter.
+// Parameters named by present are filled in elsewhere, e.g. from targets.  Only the
+// names of sensitive parameters are checked, so their values never end up in messages.
+func validateDocumentParameters(ctx context.Context, document *ssmdoc.Document, name string, parameters, sensitive ParametersValue, present ...types.String) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if parameters.IsUnknown() || sensitive.IsUnknown() {
+		return diags
+	}
+
+	values := make(map[string][]string)
+	var known []string
+
+	for _, p := range present {
+		if !p.IsNull() && !p.IsUnknown() {
+			known = append(known, p.ValueString())
+		}
+	}
+
+	sensitiveKeys := sensitiveParameterKeys(sensitive)
+
+	for _, key := range sensitiveKeys {
+		values[key] = nil
+		known = append(known, key)
+	}
+
+	for key, value := range parameters.Elements() {
+		list, ok := value.(types.List)
+		if !ok || list.IsUnknown() || containsUnknown(list) {
+			values[key] = nil
+			known = append(known, key)
+			continue
+		}
+
+		var v []string
+		diags.Append(list.ElementsAs(ctx, &v, false)...)
+		values[key] = v
+	}
+
+	if diags.HasError() {
+		return diags
+	}
+
+	for _, e := range document.ValidateParameters(values, known...) {
+		if e.Parameter == "" {
+			diags.AddAttributeError(path.Root("parameters"), "Invalid Document Parameters", fmt.Sprintf("Document %s: %s.", name, e.Message))
+			continue
+		}
+
+		diags.AddAttributeError(parameterPath(e.Parameter, sensitiveKeys), "Invalid Document Parameter", fmt.Sprintf("Document %s: %s.", name, e.Message))
+	}
+
+	return diags
+}
+
+// withDocumentDefaults adds the default value of every document parameter not set in
+// parameters, giving the values the document runs with.  Parameters named by exclude are
+// set elsewhere, e.g. from targets or as sensitive parameters, and never get defaults.
+func withDocumentDefaults(ctx context.Context, document *ssmdoc.Document, parameters ParametersValue, exclude ...string) (ParametersValue, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if parameters.IsUnknown() {
+		return parameters, diags
+	}
+
+	defaults, err := document.Defaults()
+	if err != nil {
+		diags.AddWarning("Unable to read document parameter defaults", err.Error())
+		return parameters, diags
+	}
+
+	for _, key := range exclude {
+		delete(defaults, key)
+	}
+
+	return mergeParameters(parameters, parametersOut(defaults))
+}
+
+// mergeParameters returns parameters with the entries of defaults added for every key
+// parameters does not set.  The result is null when both are empty.
+func mergeParameters(parameters, defaults ParametersValue) (ParametersValue, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	elements := make(map[string]attr.Value, len(defaults.Elements())+len(parameters.Elements()))
+
+	for key, value := range defaults.Elements() {
+		elements[key] = value
+	}
+
+	for key, value := range parameters.Elements() {
+		elements[key] = value
+	}
+
+	if len(elements) == 0 {
+		return NewParametersValueNull(), diags
+	}
+
+	mapValue, d := types.MapValue(parameterElementType, elements)
+	diags.Append(d...)
+
+	return ParametersValue{MapValue: mapValue}, diags
+}
+
+// withoutParameters returns parameters without the keys set in remove.
+func withoutParameters(parameters, remove ParametersValue) ParametersValue {
+	elements := maps.Clone(parameters.Elements())
+
+	for key := range remove.Elements() {
+		delete(elements, key)
+	}
+
+	mapValue, _ := types.MapValue(parameterElementType, elements)
+
+	return ParametersValue{MapValue: mapValue}
+}
+
+// effectiveParameters returns parameters with the defaults of the document added, or
+// parameters alone when the document cannot be read.
+func effectiveParameters(ctx context.Context, conn *ssm.Client, name, version string, parameters ParametersValue, exclude ...string) (ParametersValue, diag.Diagnostics) {
+	document, err := findDocumentContent(ctx, conn, name, version)
+	if err != nil {
+		return mergeParameters(parameters, NewParametersValueNull())
+	}
+
+	return withDocumentDefaults(ctx, document, parameters, exclude...)
+}
+
+// parameterReferenceUse is a parameter whose value references a Parameter Store
+// parameter, and whether it does so with {{ssm-secure:name}}.
+type parameterReferenceUse struct {
+	key    string
+	secure bool
+}
+
+// validateParameterReferences ensures the Parameter Store parameters referenced by
+// {{ssm:name}} and {{ssm-secure:name}} in parameter values exist and are referenced
+// according to their type, as they are otherwise only resolved when the document runs.
+func validateParameterReferences(ctx context.Context, conn *ssm.Client, parameters, sensitive ParametersValue) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if conn == nil {
+		return diags
+	}
+
+	sensitiveKeys := sensitiveParameterKeys(sensitive)
+	referencedBy := make(map[string][]parameterReferenceUse)
+
+	for _, p := range []ParametersValue{parameters, sensitive} {
+		if p.IsNull() || p.IsUnknown() {
+			continue
+		}
+
+		for key, value := range p.Elements() {
+			list, ok := value.(types.List)
+			if !ok || list.IsUnknown() {
+				continue
+			}
+
+			for _, element := range list.Elements() {
+				s, ok := element.(types.String)
+				if !ok || s.IsNull() || s.IsUnknown() {
+					continue
+				}
+
+				for _, reference := range ssmdoc.References(s.ValueString()) {
+					use := parameterReferenceUse{key: key, secure: reference.Secure}
+					if !slices.Contains(referencedBy[reference.Name], use) {
+						referencedBy[reference.Name] = append(referencedBy[reference.Name], use)
+					}
+				}
+			}
+		}
+	}
+
+	names := slices.Sorted(maps.Keys(referencedBy))
+
+	for chunk := range slices.Chunk(names, 10) {
+		output, err := conn.GetParameters(ctx, &ssm.GetParametersInput{
+			Names: chunk,
+		})
+		if err != nil {
+			diags.AddWarning("Unable to validate parameter references", err.Error())
+			return diags
+		}
+
+		for _, name := range output.InvalidParameters {
+			for _, use := range referencedBy[name] {
+				diags.AddAttributeError(
+					parameterPath(use.key, sensitiveKeys),
+					"Invalid Parameter Reference",
+					fmt.Sprintf("Parameter %q references Parameter Store parameter %s, which does not exist.", use.key, name),
+				)
+			}
+		}
+
+		for _, parameter := range output.Parameters {
+			name := aws.ToString(parameter.Name) + aws.ToString(parameter.Selector)
+			diags.Append(parameterReferenceTypeDiagnostics(name, parameter.Type, referencedBy[name], sensitiveKeys)...)
+		}
+	}
+
+	return diags
+}
+
+// parameterReferenceTypeDiagnostics reports references that do not match the type of
+// the Parameter Store parameter.  SSM only decrypts SecureString parameters referenced
+// by {{ssm-secure:name}} and rejects other parameters referenced that way.
+func parameterReferenceTypeDiagnostics(name string, parameterType awstypes.ParameterType, uses []parameterReferenceUse, sensitiveKeys []string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	secureString := parameterType == awstypes.ParameterTypeSecureString
+
+	for _, use := range uses {
+		switch {
+		case secureString && !use.secure:
+			diags.AddAttributeError(
+				parameterPath(use.key, sensitiveKeys),
+				"Invalid Parameter Reference",
+				fmt.Sprintf("Parameter %q references SecureString parameter %[2]s with {{ssm:%[2]s}}, use {{ssm-secure:%[2]s}}.", use.key, name),
+			)
+		case !secureString && use.secure:
+			diags.AddAttributeError(
+				parameterPath(use.key, sensitiveKeys),
+				"Invalid Parameter Reference",
+				fmt.Sprintf("Parameter %q references %[3]s parameter %[2]s with {{ssm-secure:%[2]s}}, use {{ssm:%[2]s}}.", use.key, name, parameterType),
+			)
+		}
+	}
+
+	return diags
+}
+
+// parameterPath returns the path of a parameter in parameters or sensitive_parameters.
+func parameterPath(key string, sensitiveKeys []string) path.Path {
+	if slices.Contains(sensitiveKeys, key) {
+		return path.Root("sensitive_parameters").AtMapKey(key)
+	}
+
+	return path.Root("parameters").AtMapKey(key)
+}
+
+// findDocumentContent returns the parsed content of a document.  An empty version
+// returns the default version of the document.
+func findDocumentContent(ctx context.Context, conn *ssm.Client, name, version string) (*ssmdoc.Document, error) {
+	input := &ssm.GetDocumentInput{
+		Name:           aws.String(name),
+		DocumentFormat: awstypes.DocumentFormatJson,
+	}
+
+	if version != "" {
+		input.DocumentVersion = aws.String(version)
+	}
+
+	output, err := conn.GetDocument(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return ssmdoc.Parse(aws.ToString(output.Content))
+}
+
+func containsUnknown(list types.List) bool {
+	for _, element := range list.Elements() {
+		if element.IsUnknown() {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,97 @@
+package provider
+
+import (
+	"context"
+	awstypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/coding-ia/terraform-provider-automation/internal/ssmdoc"
+	"reflect"
+	"testing"
+)
+
+func TestWithDocumentDefaults(t *testing.T) {
+	ctx := context.Background()
+
+	document, err := ssmdoc.Parse(`{
+  "parameters": {
+    "commands": {"type": "StringList"},
+    "executionTimeout": {"type": "String", "default": "3600"},
+    "InstanceId": {"type": "String", "default": ""}
+  }
+}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := map[string]struct {
+		parameters map[string][]string
+		want       map[string][]string
+	}{
+		"defaults": {
+			parameters: map[string][]string{"commands": {"echo hello"}},
+			want:       map[string][]string{"commands": {"echo hello"}, "executionTimeout": {"3600"}},
+		},
+		"override": {
+			parameters: map[string][]string{"commands": {"echo hello"}, "executionTimeout": {"600"}},
+			want:       map[string][]string{"commands": {"echo hello"}, "executionTimeout": {"600"}},
+		},
+		"no parameters": {
+			want: map[string][]string{"executionTimeout": {"3600"}},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			parameters := NewParametersValueNull()
+			if testCase.parameters != nil {
+				parameters = parametersOut(testCase.parameters)
+			}
+
+			got, diags := withDocumentDefaults(ctx, document, parameters, "InstanceId")
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			if values := parametersIn(ctx, got.Elements()); !reflect.DeepEqual(values, testCase.want) {
+				t.Errorf("expected %v, got %v", testCase.want, values)
+			}
+		})
+	}
+}
+
+func TestWithoutParameters(t *testing.T) {
+	ctx := context.Background()
+
+	effective := parametersOut(map[string][]string{"commands": {"echo hello"}, "executionTimeout": {"3600"}})
+	configured := parametersOut(map[string][]string{"commands": {"echo hello"}})
+
+	got := parametersIn(ctx, withoutParameters(effective, configured).Elements())
+	want := map[string][]string{"executionTimeout": {"3600"}}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestParameterReferenceTypeDiagnostics(t *testing.T) {
+	testCases := map[string]struct {
+		parameterType awstypes.ParameterType
+		secure        bool
+		err           bool
+	}{
+		"string":                    {parameterType: awstypes.ParameterTypeString},
+		"secure string":             {parameterType: awstypes.ParameterTypeSecureString, secure: true},
+		"secure string without ssm": {parameterType: awstypes.ParameterTypeSecureString, err: true},
+		"string with ssm-secure":    {parameterType: awstypes.ParameterTypeString, secure: true, err: true},
+		"string list":               {parameterType: awstypes.ParameterTypeStringList},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			uses := []parameterReferenceUse{{key: "password", secure: testCase.secure}}
+
+			if diags := parameterReferenceTypeDiagnostics("/app/password", testCase.parameterType, uses, nil); diags.HasError() != testCase.err {
+				t.Errorf("expected error %t, got %v", testCase.err, diags)
+			}
+		})
+	}
+}
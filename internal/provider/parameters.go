@@ -0,0 +1,120 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"slices"
+)
+
+var parameterElementType = types.ListType{ElemType: types.StringType}
+
+var _ basetypes.MapTypable = ParametersType{}
+var _ basetypes.MapValuableWithSemanticEquals = ParametersValue{}
+
+// ParametersType is the map type for document parameters.  SSM keeps the order of
+// parameter values (e.g. the commands of AWS-RunShellScript), so values are compared in
+// order, but a parameter with no values is equal to the parameter being absent.
+type ParametersType struct {
+	basetypes.MapType
+}
+
+func NewParametersType() ParametersType {
+	return ParametersType{
+		MapType: types.MapType{ElemType: parameterElementType},
+	}
+}
+
+func (t ParametersType) Equal(o attr.Type) bool {
+	other, ok := o.(ParametersType)
+	if !ok {
+		return false
+	}
+
+	return t.MapType.Equal(other.MapType)
+}
+
+func (t ParametersType) String() string {
+	return "ParametersType"
+}
+
+func (t ParametersType) ValueFromMap(_ context.Context, in basetypes.MapValue) (basetypes.MapValuable, diag.Diagnostics) {
+	return ParametersValue{
+		MapValue: in,
+	}, nil
+}
+
+func (t ParametersType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.MapType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	mapValue, ok := attrValue.(basetypes.MapValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	return ParametersValue{
+		MapValue: mapValue,
+	}, nil
+}
+
+func (t ParametersType) ValueType(_ context.Context) attr.Value {
+	return ParametersValue{}
+}
+
+type ParametersValue struct {
+	basetypes.MapValue
+}
+
+func NewParametersValueNull() ParametersValue {
+	return ParametersValue{
+		MapValue: types.MapNull(parameterElementType),
+	}
+}
+
+func (v ParametersValue) Equal(o attr.Value) bool {
+	other, ok := o.(ParametersValue)
+	if !ok {
+		return false
+	}
+
+	return v.MapValue.Equal(other.MapValue)
+}
+
+func (v ParametersValue) Type(_ context.Context) attr.Type {
+	return NewParametersType()
+}
+
+func (v ParametersValue) MapSemanticEquals(ctx context.Context, newValuable basetypes.MapValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(ParametersValue)
+	if !ok {
+		diags.AddError("Semantic Equality Check Error", fmt.Sprintf("Expected value type %T, got %T", v, newValuable))
+		return false, diags
+	}
+
+	return parametersEqual(parametersIn(ctx, v.Elements()), parametersIn(ctx, newValue.Elements())), diags
+}
+
+func parametersEqual(a, b map[string][]string) bool {
+	for k, v := range a {
+		if !slices.Equal(v, b[k]) {
+			return false
+		}
+	}
+
+	for k, v := range b {
+		if _, ok := a[k]; !ok && len(v) > 0 {
+			return false
+		}
+	}
+
+	return true
+}
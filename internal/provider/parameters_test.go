@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"context"
+	"testing"
+)
+
+func TestParametersValue_MapSemanticEquals(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := map[string]struct {
+		prior    map[string][]string
+		proposed map[string][]string
+		want     bool
+	}{
+		"identical": {
+			prior:    map[string][]string{"commands": {"a", "b"}},
+			proposed: map[string][]string{"commands": {"a", "b"}},
+			want:     true,
+		},
+		"value order": {
+			prior:    map[string][]string{"commands": {"a", "b"}},
+			proposed: map[string][]string{"commands": {"b", "a"}},
+			want:     false,
+		},
+		"empty parameter": {
+			prior:    map[string][]string{"commands": {"a"}},
+			proposed: map[string][]string{"commands": {"a"}, "workingDirectory": {}},
+			want:     true,
+		},
+		"missing parameter": {
+			prior:    map[string][]string{"commands": {"a"}, "workingDirectory": {"/tmp"}},
+			proposed: map[string][]string{"commands": {"a"}},
+			want:     false,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, diags := parametersOut(testCase.prior).MapSemanticEquals(ctx, parametersOut(testCase.proposed))
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			if got != testCase.want {
+				t.Errorf("expected %t, got %t", testCase.want, got)
+			}
+		})
+	}
+}
@@ -61,17 +61,30 @@ func (ap *AutomationProvider) Configure(ctx context.Context, request provider.Co
 	client := conn.CreateAWSClient(ctx, opts)
 	ap.Meta.AWSClient = *client
 
+	response.DataSourceData = ap.Meta
 	response.ResourceData = ap.Meta
 }
 
 func (ap *AutomationProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
-	return []func() datasource.DataSource{}
+	return []func() datasource.DataSource{
+		newAWSSSMAssociationComplianceDataSource,
+		newAWSSSMAssociationExecutionsDataSource,
+		newAWSSSMAssociationVersionsDataSource,
+		newAWSSSMAssociationsDataSource,
+		newAWSSSMAutomationExecutionDataSource,
+		newAWSSSMDocumentDataSource,
+		newAWSSSMManagedInstancesDataSource,
+	}
 }
 
 func (ap *AutomationProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		newAWSSSMAssociationResource,
+		newAWSSSMComplianceItemsResource,
 		newAWSSSMStartAutomationExecutionResource,
+		newAWSSSMSendCommandResource,
+		newAWSSSMDocumentResource,
+		newAWSSSMDocumentDefaultVersionResource,
 	}
 }
 
@@ -0,0 +1,146 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"maps"
+	"slices"
+)
+
+// privateKeySensitiveParameters is the private state key holding the names, never the
+// values, of the sensitive parameters sent to AWS, so they can be left out of the
+// parameters read back into state.  Imported resources record null, as the sensitive
+// parameters are only known once they are sent to AWS again.
+const privateKeySensitiveParameters = "sensitive_parameters"
+
+// privateState is implemented by the private state of resource requests and responses.
+type privateState interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// sensitiveParametersAttribute is the schema of the write-only parameters shared by
+// resources accepting document parameters.
+func sensitiveParametersAttribute() schema.MapAttribute {
+	return schema.MapAttribute{
+		Description: "Parameters for the runtime configuration of the document that are never stored in state, e.g. passwords.  Merged with parameters when sent to AWS.  Changes are only sent when sensitive_parameters_version changes.  Requires Terraform 1.11 or later.",
+		Optional:    true,
+		Sensitive:   true,
+		WriteOnly:   true,
+		CustomType:  NewParametersType(),
+		ElementType: types.ListType{ElemType: types.StringType},
+	}
+}
+
+// sensitiveParametersVersionAttribute is the schema of the version triggering a change
+// of the write-only sensitive parameters.
+func sensitiveParametersVersionAttribute(planModifiers ...planmodifier.Int32) schema.Int32Attribute {
+	return schema.Int32Attribute{
+		Description:   "A version of sensitive_parameters.  Change it to send changed sensitive_parameters to AWS.",
+		Optional:      true,
+		PlanModifiers: planModifiers,
+	}
+}
+
+// validateSensitiveParameters ensures no parameter is set both as a regular and as a
+// sensitive parameter.
+func validateSensitiveParameters(parameters, sensitive ParametersValue) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for _, key := range sensitiveParameterKeys(sensitive) {
+		if _, ok := parameters.Elements()[key]; ok {
+			diags.AddAttributeError(
+				path.Root("sensitive_parameters").AtMapKey(key),
+				"Invalid Attribute Combination",
+				fmt.Sprintf("Parameter %q is set in both parameters and sensitive_parameters.", key),
+			)
+		}
+	}
+
+	return diags
+}
+
+// withSensitiveParameters returns the parameters sent to AWS with the sensitive
+// parameters added.
+func withSensitiveParameters(ctx context.Context, parameters map[string][]string, sensitive ParametersValue) map[string][]string {
+	if sensitive.IsNull() || sensitive.IsUnknown() {
+		return parameters
+	}
+
+	if parameters == nil {
+		parameters = make(map[string][]string)
+	}
+
+	maps.Copy(parameters, parametersIn(ctx, sensitive.Elements()))
+
+	return parameters
+}
+
+// withoutSensitiveParameters returns the parameters read from AWS without the sensitive
+// parameters.
+func withoutSensitiveParameters(parameters map[string][]string, keys []string) map[string][]string {
+	if len(keys) == 0 {
+		return parameters
+	}
+
+	result := maps.Clone(parameters)
+
+	for _, key := range keys {
+		delete(result, key)
+	}
+
+	return result
+}
+
+func sensitiveParameterKeys(sensitive ParametersValue) []string {
+	if sensitive.IsNull() || sensitive.IsUnknown() {
+		return nil
+	}
+
+	return slices.Sorted(maps.Keys(sensitive.Elements()))
+}
+
+// getSensitiveParameterKeys returns the names of the sensitive parameters and whether
+// they are known.  Resources without the private state key predate sensitive parameters
+// and have none.
+func getSensitiveParameterKeys(ctx context.Context, private privateState) ([]string, bool, diag.Diagnostics) {
+	data, diags := private.GetKey(ctx, privateKeySensitiveParameters)
+
+	if diags.HasError() || len(data) == 0 {
+		return nil, true, diags
+	}
+
+	var keys []string
+	if err := json.Unmarshal(data, &keys); err != nil {
+		diags.AddError("Error reading private state", err.Error())
+	}
+
+	return keys, keys != nil, diags
+}
+
+func setSensitiveParameterKeys(ctx context.Context, private privateState, keys []string) diag.Diagnostics {
+	if keys == nil {
+		keys = []string{}
+	}
+
+	data, err := json.Marshal(keys)
+	if err != nil {
+		var diags diag.Diagnostics
+		diags.AddError("Error writing private state", err.Error())
+		return diags
+	}
+
+	return private.SetKey(ctx, privateKeySensitiveParameters, data)
+}
+
+// setSensitiveParameterKeysUnknown records that any parameter may be sensitive, e.g.
+// for imported resources.
+func setSensitiveParameterKeysUnknown(ctx context.Context, private privateState) diag.Diagnostics {
+	return private.SetKey(ctx, privateKeySensitiveParameters, []byte("null"))
+}
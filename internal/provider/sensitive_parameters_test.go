@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"reflect"
+	"testing"
+)
+
+func TestSensitiveParameters(t *testing.T) {
+	ctx := context.Background()
+
+	parameters := map[string][]string{"commands": {"echo hello"}}
+	sensitive := parametersOut(map[string][]string{"password": {"secret"}})
+
+	merged := withSensitiveParameters(ctx, parameters, sensitive)
+	want := map[string][]string{"commands": {"echo hello"}, "password": {"secret"}}
+
+	if !reflect.DeepEqual(merged, want) {
+		t.Errorf("expected %v, got %v", want, merged)
+	}
+
+	stripped := withoutSensitiveParameters(merged, sensitiveParameterKeys(sensitive))
+	want = map[string][]string{"commands": {"echo hello"}}
+
+	if !reflect.DeepEqual(stripped, want) {
+		t.Errorf("expected %v, got %v", want, stripped)
+	}
+
+	if got := withSensitiveParameters(ctx, nil, NewParametersValueNull()); got != nil {
+		t.Errorf("expected nil parameters, got %v", got)
+	}
+}
+
+func TestValidateSensitiveParameters(t *testing.T) {
+	parameters := parametersOut(map[string][]string{"commands": {"echo hello"}, "password": {"secret"}})
+
+	if diags := validateSensitiveParameters(parameters, parametersOut(map[string][]string{"token": {"secret"}})); diags.HasError() {
+		t.Errorf("unexpected diagnostics: %v", diags)
+	}
+
+	if diags := validateSensitiveParameters(parameters, parametersOut(map[string][]string{"password": {"secret"}})); !diags.HasError() {
+		t.Error("expected error for a parameter set in both parameters and sensitive_parameters")
+	}
+}
+
+// testPrivateState is an in-memory private state.
+type testPrivateState map[string][]byte
+
+func (p testPrivateState) GetKey(_ context.Context, key string) ([]byte, diag.Diagnostics) {
+	return p[key], nil
+}
+
+func (p testPrivateState) SetKey(_ context.Context, key string, value []byte) diag.Diagnostics {
+	p[key] = value
+	return nil
+}
+
+func TestSensitiveParameterKeysPrivateState(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := map[string]struct {
+		set   func(privateState) diag.Diagnostics
+		keys  []string
+		known bool
+	}{
+		"not recorded": {known: true},
+		"recorded": {
+			set: func(private privateState) diag.Diagnostics {
+				return setSensitiveParameterKeys(ctx, private, []string{"password"})
+			},
+			keys:  []string{"password"},
+			known: true,
+		},
+		"recorded without keys": {
+			set: func(private privateState) diag.Diagnostics {
+				return setSensitiveParameterKeys(ctx, private, nil)
+			},
+			keys:  []string{},
+			known: true,
+		},
+		"imported": {
+			set: func(private privateState) diag.Diagnostics {
+				return setSensitiveParameterKeysUnknown(ctx, private)
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			private := testPrivateState{}
+
+			if testCase.set != nil {
+				if diags := testCase.set(private); diags.HasError() {
+					t.Fatalf("unexpected diagnostics: %v", diags)
+				}
+			}
+
+			keys, known, diags := getSensitiveParameterKeys(ctx, private)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			if !reflect.DeepEqual(keys, testCase.keys) || known != testCase.known {
+				t.Errorf("expected %v %t, got %v %t", testCase.keys, testCase.known, keys, known)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+// stateUpgradeStep upgrades the raw JSON state of a resource by a single schema version,
+// modifying the decoded state in place.
+type stateUpgradeStep func(state map[string]any) error
+
+// stateUpgraders returns the state upgraders of a resource whose schema version is the
+// number of steps, where steps[i] upgrades version i to version i+1.  State of any prior
+// version is upgraded by running all remaining steps in order, so a schema change only
+// needs a single new step appended and the schema version incremented.
+//
+// Upgraders work on raw JSON, so no prior schema needs to be kept around.  Attributes
+// missing from the upgraded state are read as null.
+func stateUpgraders(steps ...stateUpgradeStep) map[int64]resource.StateUpgrader {
+	upgraders := make(map[int64]resource.StateUpgrader, len(steps))
+
+	for version := range steps {
+		upgraders[int64(version)] = resource.StateUpgrader{
+			StateUpgrader: func(_ context.Context, request resource.UpgradeStateRequest, response *resource.UpgradeStateResponse) {
+				if request.RawState == nil || request.RawState.JSON == nil {
+					response.Diagnostics.AddError("Unable to upgrade state", "Prior state is not in JSON format.")
+					return
+				}
+
+				data, err := upgradeStateJSON(request.RawState.JSON, int64(version), steps)
+				if err != nil {
+					response.Diagnostics.AddError("Unable to upgrade state", err.Error())
+					return
+				}
+
+				response.DynamicValue = &tfprotov6.DynamicValue{
+					JSON: data,
+				}
+			},
+		}
+	}
+
+	return upgraders
+}
+
+// upgradeStateJSON upgrades raw JSON state of the given version to the latest version.
+func upgradeStateJSON(data []byte, version int64, steps []stateUpgradeStep) ([]byte, error) {
+	if version < 0 || version > int64(len(steps)) {
+		return nil, fmt.Errorf("unsupported state version %d, expected at most %d", version, len(steps))
+	}
+
+	var state map[string]any
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("decoding state version %d: %w", version, err)
+	}
+
+	if state == nil {
+		return nil, fmt.Errorf("state version %d is not an object", version)
+	}
+
+	for v := version; v < int64(len(steps)); v++ {
+		if err := steps[v](state); err != nil {
+			return nil, fmt.Errorf("upgrading state from version %d to %d: %w", v, v+1, err)
+		}
+	}
+
+	return json.Marshal(state)
+}
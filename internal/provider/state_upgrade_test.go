@@ -0,0 +1,178 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// stateUpgradeFixture is a prior state of a resource and the state expected after
+// upgrading it to the current schema version.  Fixtures are stored as
+// testdata/state_upgrade/<resource type>/<name>.json.
+type stateUpgradeFixture struct {
+	Version  int64           `json:"version"`
+	State    json.RawMessage `json:"state"`
+	Expected json.RawMessage `json:"expected"`
+}
+
+func TestResourceStateUpgrade(t *testing.T) {
+	ctx := context.Background()
+
+	for _, newResource := range New("test")().Resources(ctx) {
+		r := newResource()
+
+		var metadata resource.MetadataResponse
+		r.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: "automation"}, &metadata)
+
+		t.Run(metadata.TypeName, func(t *testing.T) {
+			var schema resource.SchemaResponse
+			r.Schema(ctx, resource.SchemaRequest{}, &schema)
+
+			if schema.Diagnostics.HasError() {
+				t.Fatalf("unexpected schema diagnostics: %v", schema.Diagnostics)
+			}
+
+			version := schema.Schema.Version
+			if version == 0 {
+				return
+			}
+
+			upgradable, ok := r.(resource.ResourceWithUpgradeState)
+			if !ok {
+				t.Fatalf("schema version is %d but resource does not implement UpgradeState", version)
+			}
+
+			upgraders := upgradable.UpgradeState(ctx)
+
+			for v := int64(0); v < version; v++ {
+				if _, ok := upgraders[v]; !ok {
+					t.Errorf("no state upgrader for version %d", v)
+				}
+			}
+
+			files, err := filepath.Glob(filepath.Join("testdata", "state_upgrade", metadata.TypeName, "*.json"))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			covered := make(map[int64]bool)
+
+			for _, file := range files {
+				var fixture stateUpgradeFixture
+
+				data, err := os.ReadFile(file)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if err := json.Unmarshal(data, &fixture); err != nil {
+					t.Fatalf("decoding %s: %s", file, err)
+				}
+
+				covered[fixture.Version] = true
+
+				t.Run(filepath.Base(file), func(t *testing.T) {
+					upgrader, ok := upgraders[fixture.Version]
+					if !ok {
+						t.Fatalf("no state upgrader for version %d", fixture.Version)
+					}
+
+					request := resource.UpgradeStateRequest{
+						RawState: &tfprotov6.RawState{JSON: fixture.State},
+					}
+					var response resource.UpgradeStateResponse
+
+					upgrader.StateUpgrader(ctx, request, &response)
+
+					if response.Diagnostics.HasError() {
+						t.Fatalf("unexpected diagnostics: %v", response.Diagnostics)
+					}
+
+					if response.DynamicValue == nil {
+						t.Fatal("expected upgraded state")
+					}
+
+					var got, want any
+
+					if err := json.Unmarshal(response.DynamicValue.JSON, &got); err != nil {
+						t.Fatal(err)
+					}
+
+					if err := json.Unmarshal(fixture.Expected, &want); err != nil {
+						t.Fatal(err)
+					}
+
+					if !reflect.DeepEqual(got, want) {
+						t.Errorf("unexpected upgraded state\ngot:  %s\nwant: %s", response.DynamicValue.JSON, fixture.Expected)
+					}
+
+					// The upgraded state must be readable with the current schema.
+					if _, err := response.DynamicValue.Unmarshal(schema.Schema.Type().TerraformType(ctx)); err != nil {
+						t.Errorf("upgraded state does not match the current schema: %s", err)
+					}
+				})
+			}
+
+			for v := int64(0); v < version; v++ {
+				if !covered[v] {
+					t.Errorf("no state upgrade fixture for version %d", v)
+				}
+			}
+		})
+	}
+}
+
+func TestUpgradeStateJSON(t *testing.T) {
+	steps := []stateUpgradeStep{
+		func(state map[string]any) error {
+			state["first"] = state["zero"]
+			delete(state, "zero")
+			return nil
+		},
+		func(state map[string]any) error {
+			state["second"] = state["first"]
+			delete(state, "first")
+			return nil
+		},
+	}
+
+	testCases := map[string]struct {
+		state   string
+		version int64
+		want    string
+		wantErr bool
+	}{
+		"version 0":       {state: `{"zero":"a"}`, version: 0, want: `{"second":"a"}`},
+		"version 1":       {state: `{"first":"a"}`, version: 1, want: `{"second":"a"}`},
+		"current version": {state: `{"second":"a"}`, version: 2, want: `{"second":"a"}`},
+		"future version":  {state: `{"third":"a"}`, version: 3, wantErr: true},
+		"not an object":   {state: `null`, version: 0, wantErr: true},
+		"invalid json":    {state: `{`, version: 0, wantErr: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := upgradeStateJSON([]byte(testCase.state), testCase.version, steps)
+
+			if testCase.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %s", got)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if string(got) != testCase.want {
+				t.Errorf("expected %s, got %s", testCase.want, got)
+			}
+		})
+	}
+}
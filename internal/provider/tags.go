@@ -1,7 +1,9 @@
 package provider
 
 import (
+	"context"
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
 	awstypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -33,3 +35,62 @@ func tagsOut(tags []awstypes.Tag) (types.Map, diag.Diagnostics) {
 
 	return mapVal, d
 }
+
+func findTags(ctx context.Context, conn *ssm.Client, resourceType awstypes.ResourceTypeForTagging, id string) ([]awstypes.Tag, error) {
+	input := &ssm.ListTagsForResourceInput{
+		ResourceId:   aws.String(id),
+		ResourceType: resourceType,
+	}
+
+	output, err := conn.ListTagsForResource(ctx, input)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return output.TagList, nil
+}
+
+// updateTags adds new and changed tags and removes the tags no longer configured.
+func updateTags(ctx context.Context, conn *ssm.Client, resourceType awstypes.ResourceTypeForTagging, id string, oldTags, newTags types.Map) error {
+	oldElements := oldTags.Elements()
+	newElements := newTags.Elements()
+
+	var removed []string
+	for k := range oldElements {
+		if _, ok := newElements[k]; !ok {
+			removed = append(removed, k)
+		}
+	}
+
+	if len(removed) > 0 {
+		_, err := conn.RemoveTagsFromResource(ctx, &ssm.RemoveTagsFromResourceInput{
+			ResourceId:   aws.String(id),
+			ResourceType: resourceType,
+			TagKeys:      removed,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	changed := make(map[string]attr.Value)
+	for k, v := range newElements {
+		if old, ok := oldElements[k]; !ok || !old.Equal(v) {
+			changed[k] = v
+		}
+	}
+
+	if len(changed) > 0 {
+		_, err := conn.AddTagsToResource(ctx, &ssm.AddTagsToResourceInput{
+			ResourceId:   aws.String(id),
+			ResourceType: resourceType,
+			Tags:         tagsIn(changed),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
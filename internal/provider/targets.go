@@ -0,0 +1,245 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awstypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"slices"
+	"strings"
+)
+
+var _ planmodifier.List = noOpAutomationTargetsModifier{}
+
+// noOpAutomationTargetsModifier plans targets for associations where AWS injects the
+// aws:NoOpAutomationTag target when an Automation runbook association has no targets.
+// The injected target is treated as equivalent to leaving targets unset.
+type noOpAutomationTargetsModifier struct{}
+
+func useNoOpAutomationTargets() planmodifier.List {
+	return noOpAutomationTargetsModifier{}
+}
+
+func (m noOpAutomationTargetsModifier) Description(ctx context.Context) string {
+	return m.MarkdownDescription(ctx)
+}
+
+func (m noOpAutomationTargetsModifier) MarkdownDescription(_ context.Context) string {
+	return "Treats the AWS generated aws:NoOpAutomationTag target as equivalent to unset targets."
+}
+
+func (m noOpAutomationTargetsModifier) PlanModifyList(ctx context.Context, request planmodifier.ListRequest, response *planmodifier.ListResponse) {
+	// Nothing to compare against on create, or targets are configured explicitly.
+	if request.State.Raw.IsNull() || request.Plan.Raw.IsNull() || !request.ConfigValue.IsNull() {
+		return
+	}
+
+	if request.StateValue.IsNull() || request.StateValue.IsUnknown() {
+		return
+	}
+
+	// Unset targets with no targets or only the injected target in state is a no-op.
+	stateTargets, diags := targetsIn(ctx, request.StateValue)
+	response.Diagnostics.Append(diags...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	if len(stateTargets) == 0 || isAutoSSMTarget(stateTargets) {
+		response.PlanValue = request.StateValue
+		return
+	}
+
+	// UpdateAssociation cannot remove targets, so going from real targets back to unset
+	// requires a new association for AWS to inject the NoOp target again.
+	response.RequiresReplace = true
+}
+
+type TargetModel struct {
+	Key    types.String `tfsdk:"key"`
+	Values types.List   `tfsdk:"values"`
+}
+
+var targetObjectType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"key":    types.StringType,
+		"values": types.ListType{ElemType: types.StringType},
+	},
+}
+
+// targetNestedObject is the schema of a single target shared by resources accepting targets.
+func targetNestedObject() schema.NestedAttributeObject {
+	return schema.NestedAttributeObject{
+		Attributes: map[string]schema.Attribute{
+			"key": schema.StringAttribute{
+				Description: "The target key, e.g. InstanceIds, tag:<tag-key>, tag-key, resource-groups:Name or ParameterValues.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 163),
+				},
+			},
+			"values": schema.ListAttribute{
+				Description: "The values for the target key.",
+				Required:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeBetween(0, 50),
+				},
+			},
+		},
+	}
+}
+
+var _ basetypes.ListTypable = TargetsType{}
+var _ basetypes.ListValuableWithSemanticEquals = TargetsValue{}
+
+// TargetsType is the list type for targets.  Targets are semantically equal regardless
+// of the order of the targets or the order of the values within a target.
+type TargetsType struct {
+	basetypes.ListType
+}
+
+func NewTargetsType() TargetsType {
+	return TargetsType{
+		ListType: types.ListType{ElemType: targetObjectType},
+	}
+}
+
+func (t TargetsType) Equal(o attr.Type) bool {
+	other, ok := o.(TargetsType)
+	if !ok {
+		return false
+	}
+
+	return t.ListType.Equal(other.ListType)
+}
+
+func (t TargetsType) String() string {
+	return "TargetsType"
+}
+
+func (t TargetsType) ValueFromList(_ context.Context, in basetypes.ListValue) (basetypes.ListValuable, diag.Diagnostics) {
+	return TargetsValue{
+		ListValue: in,
+	}, nil
+}
+
+func (t TargetsType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.ListType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	listValue, ok := attrValue.(basetypes.ListValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	return TargetsValue{
+		ListValue: listValue,
+	}, nil
+}
+
+func (t TargetsType) ValueType(_ context.Context) attr.Value {
+	return TargetsValue{}
+}
+
+type TargetsValue struct {
+	basetypes.ListValue
+}
+
+func NewTargetsValueNull() TargetsValue {
+	return TargetsValue{
+		ListValue: types.ListNull(targetObjectType),
+	}
+}
+
+func (v TargetsValue) Equal(o attr.Value) bool {
+	other, ok := o.(TargetsValue)
+	if !ok {
+		return false
+	}
+
+	return v.ListValue.Equal(other.ListValue)
+}
+
+func (v TargetsValue) Type(_ context.Context) attr.Type {
+	return NewTargetsType()
+}
+
+func (v TargetsValue) ListSemanticEquals(ctx context.Context, newValuable basetypes.ListValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(TargetsValue)
+	if !ok {
+		diags.AddError("Semantic Equality Check Error", fmt.Sprintf("Expected value type %T, got %T", v, newValuable))
+		return false, diags
+	}
+
+	priorTargets, d := targetsIn(ctx, v.ListValue)
+	diags.Append(d...)
+
+	newTargets, d := targetsIn(ctx, newValue.ListValue)
+	diags.Append(d...)
+
+	if diags.HasError() {
+		return false, diags
+	}
+
+	return slices.Equal(canonicalTargets(priorTargets), canonicalTargets(newTargets)), diags
+}
+
+// canonicalTargets renders targets in a sorted form that ignores target and value order.
+func canonicalTargets(targets []awstypes.Target) []string {
+	result := make([]string, 0, len(targets))
+
+	for _, target := range targets {
+		values := slices.Clone(target.Values)
+		slices.Sort(values)
+		result = append(result, aws.ToString(target.Key)+"="+strings.Join(values, "\x00"))
+	}
+
+	slices.Sort(result)
+
+	return result
+}
+
+// upgradeTargetsStateV0 migrates state written while targets was a list of objects
+// rather than a nested attribute.  The stored shape is the same, but targets without a
+// key are dropped and missing values become empty lists, as both are now required.
+func upgradeTargetsStateV0(state map[string]any) error {
+	targets, ok := state["targets"].([]any)
+	if !ok {
+		return nil
+	}
+
+	upgraded := make([]any, 0, len(targets))
+
+	for _, t := range targets {
+		target, ok := t.(map[string]any)
+		if !ok || target["key"] == nil {
+			continue
+		}
+
+		if target["values"] == nil {
+			target["values"] = []any{}
+		}
+
+		upgraded = append(upgraded, target)
+	}
+
+	state["targets"] = upgraded
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package provider
+
+import (
+	"context"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awstypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"testing"
+)
+
+func TestTargetsValue_ListSemanticEquals(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := map[string]struct {
+		prior    []awstypes.Target
+		proposed []awstypes.Target
+		want     bool
+	}{
+		"identical": {
+			prior:    []awstypes.Target{{Key: aws.String("tag:Name"), Values: []string{"a", "b"}}},
+			proposed: []awstypes.Target{{Key: aws.String("tag:Name"), Values: []string{"a", "b"}}},
+			want:     true,
+		},
+		"value order": {
+			prior:    []awstypes.Target{{Key: aws.String("InstanceIds"), Values: []string{"i-2", "i-1"}}},
+			proposed: []awstypes.Target{{Key: aws.String("InstanceIds"), Values: []string{"i-1", "i-2"}}},
+			want:     true,
+		},
+		"target order": {
+			prior: []awstypes.Target{
+				{Key: aws.String("tag:Name"), Values: []string{"a"}},
+				{Key: aws.String("tag:Env"), Values: []string{"prod"}},
+			},
+			proposed: []awstypes.Target{
+				{Key: aws.String("tag:Env"), Values: []string{"prod"}},
+				{Key: aws.String("tag:Name"), Values: []string{"a"}},
+			},
+			want: true,
+		},
+		"different value": {
+			prior:    []awstypes.Target{{Key: aws.String("tag:Name"), Values: []string{"a"}}},
+			proposed: []awstypes.Target{{Key: aws.String("tag:Name"), Values: []string{"b"}}},
+			want:     false,
+		},
+		"different key": {
+			prior:    []awstypes.Target{{Key: aws.String("tag:Name"), Values: []string{"a"}}},
+			proposed: []awstypes.Target{{Key: aws.String("tag:Env"), Values: []string{"a"}}},
+			want:     false,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			prior, diags := targetsOut(ctx, testCase.prior)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			proposed, diags := targetsOut(ctx, testCase.proposed)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			got, diags := prior.ListSemanticEquals(ctx, proposed)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			if got != testCase.want {
+				t.Errorf("expected %t, got %t", testCase.want, got)
+			}
+		})
+	}
+}
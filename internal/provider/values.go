@@ -5,6 +5,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws"
 	awstypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -43,6 +44,25 @@ func SetFrameworkFromStringPointer(state *types.String, value *string) {
 	}
 }
 
+func SetFrameworkFromStringList(ctx context.Context, state *types.List, values []string) diag.Diagnostics {
+	if len(values) == 0 && state.IsNull() {
+		return nil
+	}
+
+	listVal, diags := types.ListValueFrom(ctx, types.StringType, values)
+	if diags.HasError() {
+		return diags
+	}
+
+	*state = listVal
+
+	return diags
+}
+
+func SetFrameworkFromInt32Pointer(state *types.Int32, value *int32) {
+	*state = types.Int32PointerValue(value)
+}
+
 func SetFrameworkFromBool(state *types.Bool, value bool) {
 	*state = types.BoolValue(value)
 }
@@ -64,67 +84,82 @@ func SetFrameworkFromOutputLocationModel(state *[]OutputLocationModel, value *aw
 	*state = append(outputLocations, *outputLocation)
 }
 
-func targetsIn(targetList types.List) []awstypes.Target {
+func SetFrameworkFromAlarmConfigurationModel(ctx context.Context, state *[]AlarmConfigurationModel, value *awstypes.AlarmConfiguration) diag.Diagnostics {
+	if value == nil {
+		return nil
+	}
+
+	var names []string
+	for _, alarm := range value.Alarms {
+		names = append(names, aws.ToString(alarm.Name))
+	}
+
+	alarms, diags := types.ListValueFrom(ctx, types.StringType, names)
+	if diags.HasError() {
+		return diags
+	}
+
+	*state = []AlarmConfigurationModel{
+		{
+			Alarms:                 alarms,
+			IgnorePollAlarmFailure: types.BoolValue(value.IgnorePollAlarmFailure),
+		},
+	}
+
+	return diags
+}
+
+func targetsIn(ctx context.Context, targetList types.List) ([]awstypes.Target, diag.Diagnostics) {
+	var models []TargetModel
 	var targets []awstypes.Target
 
-	for _, targetElem := range targetList.Elements() {
-		targetObj := targetElem.(types.Object)
-		attributes := targetObj.Attributes()
-
-		target := awstypes.Target{}
-
-		for k := range attributes {
-			if k == "key" {
-				value := attributes[k].(types.String)
-				target.Key = value.ValueStringPointer()
-			}
-			if k == "values" {
-				values := attributes[k].(types.List)
-				for _, v := range values.Elements() {
-					strval := v.(types.String)
-					target.Values = append(target.Values, strval.ValueString())
-				}
-			}
+	if targetList.IsNull() || targetList.IsUnknown() {
+		return nil, nil
+	}
+
+	diags := targetList.ElementsAs(ctx, &models, false)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	for _, model := range models {
+		target := awstypes.Target{
+			Key: model.Key.ValueStringPointer(),
 		}
 
+		diags.Append(model.Values.ElementsAs(ctx, &target.Values, true)...)
+
 		targets = append(targets, target)
 	}
 
-	return targets
+	return targets, diags
 }
 
-func targetsOut(ctx context.Context, targetsOutput []awstypes.Target) types.List {
-	var values []attr.Value
+func targetsOut(ctx context.Context, targetsOutput []awstypes.Target) (TargetsValue, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	models := make([]TargetModel, 0, len(targetsOutput))
 
 	for _, t := range targetsOutput {
-		v, _ := types.ListValueFrom(ctx, types.StringType, t.Values)
-		elements, _ := types.ListValue(types.StringType, v.Elements())
-
-		objVal, _ := types.ObjectValue(
-			map[string]attr.Type{
-				"key":    types.StringType,
-				"values": types.ListType{ElemType: types.StringType},
-			},
-			map[string]attr.Value{
-				"key":    types.StringValue(aws.ToString(t.Key)),
-				"values": elements,
-			},
-		)
-
-		values = append(values, objVal)
-	}
-
-	targetsList, _ := types.ListValue(
-		types.ObjectType{
-			AttrTypes: map[string]attr.Type{
-				"key":    types.StringType,
-				"values": types.ListType{ElemType: types.StringType},
-			},
-		},
-		values,
-	)
+		values, d := types.ListValueFrom(ctx, types.StringType, t.Values)
+		diags.Append(d...)
+
+		models = append(models, TargetModel{
+			Key:    types.StringPointerValue(t.Key),
+			Values: values,
+		})
+	}
 
-	return targetsList
+	targetsList, d := types.ListValueFrom(ctx, targetObjectType, models)
+	diags.Append(d...)
+
+	if diags.HasError() {
+		return NewTargetsValueNull(), diags
+	}
+
+	return TargetsValue{
+		ListValue: targetsList,
+	}, diags
 }
 
 func parametersIn(ctx context.Context, parameters map[string]attr.Value) map[string][]string {
@@ -140,7 +175,7 @@ func parametersIn(ctx context.Context, parameters map[string]attr.Value) map[str
 	return inputParameters
 }
 
-func parametersOut(input map[string][]string) types.Map {
+func parametersOut(input map[string][]string) ParametersValue {
 	attrMap := make(map[string]attr.Value, len(input))
 
 	for key, values := range input {
@@ -151,12 +186,14 @@ func parametersOut(input map[string][]string) types.Map {
 
 		listValue, err := types.ListValue(types.StringType, listAttrValues)
 		if err != nil {
-			return types.Map{}
+			return NewParametersValueNull()
 		}
 
 		attrMap[key] = listValue
 	}
-	mapVal, _ := types.MapValue(types.ListType{ElemType: types.StringType}, attrMap)
+	mapVal, _ := types.MapValue(parameterElementType, attrMap)
 
-	return mapVal
+	return ParametersValue{
+		MapValue: mapVal,
+	}
 }
@@ -0,0 +1,339 @@
+package schedule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	cronMinYear = 1970
+	cronMaxYear = 2199
+)
+
+var monthNames = map[string]int{
+	"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+	"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+}
+
+var dayNames = map[string]int{
+	"SUN": 1, "MON": 2, "TUE": 3, "WED": 4, "THU": 5, "FRI": 6, "SAT": 7,
+}
+
+// Cron is a cron(minutes hours day-of-month month day-of-week year) expression.
+type Cron struct {
+	minutes    field
+	hours      field
+	dayOfMonth dayOfMonthField
+	months     field
+	dayOfWeek  dayOfWeekField
+	years      field
+	raw        string
+}
+
+type field struct {
+	min    int
+	values []bool
+}
+
+func (f field) has(v int) bool {
+	i := v - f.min
+	return i >= 0 && i < len(f.values) && f.values[i]
+}
+
+type dayOfMonthField struct {
+	unspecified    bool
+	values         field
+	last           bool
+	lastOffset     int
+	lastWeekday    bool
+	nearestWeekday int
+}
+
+type dayOfWeekField struct {
+	unspecified bool
+	values      field
+	last        int
+	nth         int
+	nthWeek     int
+}
+
+func parseCron(body string) (*Cron, error) {
+	fields := strings.Fields(body)
+	if len(fields) != 6 {
+		return nil, fmt.Errorf("cron expression cron(%s) must have 6 fields (minutes hours day-of-month month day-of-week year), found %d", body, len(fields))
+	}
+
+	c := &Cron{
+		raw: strings.Join(fields, " "),
+	}
+
+	var err error
+
+	if c.minutes, err = parseField(fields[0], 0, 59, nil); err != nil {
+		return nil, fmt.Errorf("cron expression cron(%s) has invalid minutes: %w", body, err)
+	}
+
+	if c.hours, err = parseField(fields[1], 0, 23, nil); err != nil {
+		return nil, fmt.Errorf("cron expression cron(%s) has invalid hours: %w", body, err)
+	}
+
+	if c.dayOfMonth, err = parseDayOfMonth(fields[2]); err != nil {
+		return nil, fmt.Errorf("cron expression cron(%s) has invalid day-of-month: %w", body, err)
+	}
+
+	if c.months, err = parseField(fields[3], 1, 12, monthNames); err != nil {
+		return nil, fmt.Errorf("cron expression cron(%s) has invalid month: %w", body, err)
+	}
+
+	if c.dayOfWeek, err = parseDayOfWeek(fields[4]); err != nil {
+		return nil, fmt.Errorf("cron expression cron(%s) has invalid day-of-week: %w", body, err)
+	}
+
+	if c.years, err = parseField(fields[5], cronMinYear, cronMaxYear, nil); err != nil {
+		return nil, fmt.Errorf("cron expression cron(%s) has invalid year: %w", body, err)
+	}
+
+	if c.dayOfMonth.unspecified == c.dayOfWeek.unspecified {
+		return nil, fmt.Errorf("cron expression cron(%s) must use ? in exactly one of the day-of-month and day-of-week fields", body)
+	}
+
+	return c, nil
+}
+
+func (c *Cron) Kind() Kind {
+	return KindCron
+}
+
+func (c *Cron) String() string {
+	return "cron(" + c.raw + ")"
+}
+
+func (c *Cron) Next(after time.Time) (time.Time, bool) {
+	t := after.UTC().Truncate(time.Minute).Add(time.Minute)
+
+	for t.Year() <= cronMaxYear {
+		switch {
+		case !c.years.has(t.Year()):
+			t = time.Date(t.Year()+1, time.January, 1, 0, 0, 0, 0, time.UTC)
+		case !c.months.has(int(t.Month())):
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+		case !c.matchesDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+		case !c.hours.has(t.Hour()):
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, time.UTC)
+		case !c.minutes.has(t.Minute()):
+			t = t.Add(time.Minute)
+		default:
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+func (c *Cron) matchesDay(t time.Time) bool {
+	if c.dayOfMonth.unspecified {
+		return c.dayOfWeek.matches(t)
+	}
+
+	return c.dayOfMonth.matches(t)
+}
+
+func (d dayOfMonthField) matches(t time.Time) bool {
+	day := t.Day()
+	last := daysInMonth(t)
+
+	switch {
+	case d.last:
+		return day == last-d.lastOffset
+	case d.lastWeekday:
+		return day == nearestWeekday(t, last)
+	case d.nearestWeekday > 0:
+		return d.nearestWeekday <= last && day == nearestWeekday(t, d.nearestWeekday)
+	}
+
+	return d.values.has(day)
+}
+
+func (d dayOfWeekField) matches(t time.Time) bool {
+	weekday := int(t.Weekday()) + 1
+
+	switch {
+	case d.last > 0:
+		return weekday == d.last && t.Day()+7 > daysInMonth(t)
+	case d.nth > 0:
+		return weekday == d.nth && (t.Day()-1)/7+1 == d.nthWeek
+	}
+
+	return d.values.has(weekday)
+}
+
+func parseDayOfMonth(expr string) (dayOfMonthField, error) {
+	var d dayOfMonthField
+
+	switch {
+	case expr == "?":
+		d.unspecified = true
+	case expr == "L":
+		d.last = true
+	case expr == "LW":
+		d.lastWeekday = true
+	case strings.HasPrefix(expr, "L-"):
+		offset, err := strconv.Atoi(strings.TrimPrefix(expr, "L-"))
+		if err != nil || offset < 1 || offset > 30 {
+			return d, fmt.Errorf("%q must be L-n where n is between 1 and 30", expr)
+		}
+		d.last = true
+		d.lastOffset = offset
+	case strings.HasSuffix(expr, "W"):
+		day, err := strconv.Atoi(strings.TrimSuffix(expr, "W"))
+		if err != nil || day < 1 || day > 31 {
+			return d, fmt.Errorf("%q must be nW where n is between 1 and 31", expr)
+		}
+		d.nearestWeekday = day
+	default:
+		values, err := parseField(expr, 1, 31, nil)
+		if err != nil {
+			return d, err
+		}
+		d.values = values
+	}
+
+	return d, nil
+}
+
+func parseDayOfWeek(expr string) (dayOfWeekField, error) {
+	var d dayOfWeekField
+
+	switch {
+	case expr == "?":
+		d.unspecified = true
+	case expr == "L":
+		d.last = dayNames["SAT"]
+	case strings.HasSuffix(expr, "L"):
+		day, err := parseValue(strings.TrimSuffix(expr, "L"), 1, 7, dayNames)
+		if err != nil {
+			return d, err
+		}
+		d.last = day
+	case strings.Contains(expr, "#"):
+		day, week, _ := strings.Cut(expr, "#")
+		n, err := parseValue(day, 1, 7, dayNames)
+		if err != nil {
+			return d, err
+		}
+		w, err := strconv.Atoi(week)
+		if err != nil || w < 1 || w > 5 {
+			return d, fmt.Errorf("%q must be day#n where n is between 1 and 5", expr)
+		}
+		d.nth = n
+		d.nthWeek = w
+	default:
+		values, err := parseField(expr, 1, 7, dayNames)
+		if err != nil {
+			return d, err
+		}
+		d.values = values
+	}
+
+	return d, nil
+}
+
+// parseField parses a comma separated list of *, values, ranges and steps.
+func parseField(expr string, min, max int, names map[string]int) (field, error) {
+	f := field{
+		min:    min,
+		values: make([]bool, max-min+1),
+	}
+
+	for _, part := range strings.Split(expr, ",") {
+		base, stepExpr, hasStep := strings.Cut(part, "/")
+
+		step := 1
+		if hasStep {
+			var err error
+			step, err = strconv.Atoi(stepExpr)
+			if err != nil || step < 1 || step > max-min+1 {
+				return f, fmt.Errorf("%q has an invalid step", part)
+			}
+		}
+
+		start, end := min, max
+		switch {
+		case base == "*":
+		case strings.Contains(base, "-"):
+			from, to, _ := strings.Cut(base, "-")
+			var err error
+			if start, err = parseValue(from, min, max, names); err != nil {
+				return f, err
+			}
+			if end, err = parseValue(to, min, max, names); err != nil {
+				return f, err
+			}
+		default:
+			var err error
+			if start, err = parseValue(base, min, max, names); err != nil {
+				return f, err
+			}
+			if !hasStep {
+				end = start
+			}
+		}
+
+		// Ranges such as FRI-MON wrap around the end of the field.
+		for i, n := start, 0; n <= (end-start+max-min+1)%(max-min+1); i, n = i+1, n+1 {
+			if i > max {
+				i = min
+			}
+			if n%step == 0 {
+				f.values[i-min] = true
+			}
+		}
+	}
+
+	return f, nil
+}
+
+func parseValue(expr string, min, max int, names map[string]int) (int, error) {
+	if v, ok := names[strings.ToUpper(expr)]; ok {
+		return v, nil
+	}
+
+	v, err := strconv.Atoi(expr)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a valid value", expr)
+	}
+
+	if v < min || v > max {
+		return 0, fmt.Errorf("%d is not between %d and %d", v, min, max)
+	}
+
+	return v, nil
+}
+
+func daysInMonth(t time.Time) int {
+	return time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// nearestWeekday returns the weekday (Monday to Friday) closest to the given day of
+// the month of t without crossing into a neighbouring month.
+func nearestWeekday(t time.Time, day int) int {
+	last := daysInMonth(t)
+
+	switch time.Date(t.Year(), t.Month(), day, 0, 0, 0, 0, time.UTC).Weekday() {
+	case time.Saturday:
+		if day == 1 {
+			return 3
+		}
+		return day - 1
+	case time.Sunday:
+		if day == last {
+			return day - 2
+		}
+		return day + 1
+	}
+
+	return day
+}
@@ -0,0 +1,172 @@
+// Package schedule parses the cron(...), rate(...) and at(...) schedule expressions
+// accepted by Systems Manager and computes the times at which they fire.
+package schedule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Kind string
+
+const (
+	KindCron Kind = "cron"
+	KindRate Kind = "rate"
+	KindAt   Kind = "at"
+)
+
+// Expression is a parsed schedule expression.  All times are evaluated in UTC.
+type Expression interface {
+	Kind() Kind
+	// Next returns the first fire time strictly after the given time.  The boolean
+	// is false when the expression never fires again.
+	Next(after time.Time) (time.Time, bool)
+	String() string
+}
+
+// Parse parses a Systems Manager schedule expression.
+func Parse(expression string) (Expression, error) {
+	expression = strings.TrimSpace(expression)
+
+	kind, body, err := splitExpression(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	switch kind {
+	case KindCron:
+		return parseCron(body)
+	case KindRate:
+		return parseRate(body)
+	case KindAt:
+		return parseAt(body)
+	}
+
+	return nil, fmt.Errorf("unsupported schedule expression type %q", kind)
+}
+
+// NextN returns up to n fire times strictly after the given time.
+func NextN(expression Expression, after time.Time, n int) []time.Time {
+	var times []time.Time
+
+	for len(times) < n {
+		next, ok := expression.Next(after)
+		if !ok {
+			break
+		}
+
+		times = append(times, next)
+		after = next
+	}
+
+	return times
+}
+
+// FormatTimes renders fire times in the RFC 3339 format used in diagnostics.
+func FormatTimes(times []time.Time) string {
+	formatted := make([]string, 0, len(times))
+
+	for _, t := range times {
+		formatted = append(formatted, t.UTC().Format(time.RFC3339))
+	}
+
+	return strings.Join(formatted, ", ")
+}
+
+func splitExpression(expression string) (Kind, string, error) {
+	open := strings.Index(expression, "(")
+	if open < 0 || !strings.HasSuffix(expression, ")") {
+		return "", "", fmt.Errorf("schedule expression %q must be in the form cron(...), rate(...) or at(...)", expression)
+	}
+
+	kind := Kind(strings.TrimSpace(expression[:open]))
+	switch kind {
+	case KindCron, KindRate, KindAt:
+	default:
+		return "", "", fmt.Errorf("schedule expression %q must be in the form cron(...), rate(...) or at(...)", expression)
+	}
+
+	return kind, strings.TrimSpace(expression[open+1 : len(expression)-1]), nil
+}
+
+// Rate is a rate(value unit) expression.  Rate schedules are relative to the time the
+// schedule is created, so Next counts intervals from the given time.
+type Rate struct {
+	Interval time.Duration
+	raw      string
+}
+
+func parseRate(body string) (*Rate, error) {
+	fields := strings.Fields(body)
+	if len(fields) != 2 {
+		return nil, fmt.Errorf("rate expression rate(%s) must contain a value and a unit, e.g. rate(30 minutes)", body)
+	}
+
+	value, err := strconv.Atoi(fields[0])
+	if err != nil || value < 1 {
+		return nil, fmt.Errorf("rate expression rate(%s) must have a positive whole number value", body)
+	}
+
+	var unit time.Duration
+	switch strings.TrimSuffix(fields[1], "s") {
+	case "minute":
+		unit = time.Minute
+	case "hour":
+		unit = time.Hour
+	case "day":
+		unit = 24 * time.Hour
+	default:
+		return nil, fmt.Errorf("rate expression rate(%s) has unit %q, expected minutes, hours or days", body, fields[1])
+	}
+
+	return &Rate{
+		Interval: time.Duration(value) * unit,
+		raw:      body,
+	}, nil
+}
+
+func (r *Rate) Kind() Kind {
+	return KindRate
+}
+
+func (r *Rate) Next(after time.Time) (time.Time, bool) {
+	return after.UTC().Add(r.Interval), true
+}
+
+func (r *Rate) String() string {
+	return "rate(" + r.raw + ")"
+}
+
+// At is a one-time at(yyyy-mm-ddThh:mm:ss) expression.
+type At struct {
+	Time time.Time
+}
+
+func parseAt(body string) (*At, error) {
+	t, err := time.Parse("2006-01-02T15:04:05", body)
+	if err != nil {
+		return nil, fmt.Errorf("at expression at(%s) must be in the form at(yyyy-mm-ddThh:mm:ss)", body)
+	}
+
+	return &At{
+		Time: t.UTC(),
+	}, nil
+}
+
+func (a *At) Kind() Kind {
+	return KindAt
+}
+
+func (a *At) Next(after time.Time) (time.Time, bool) {
+	if a.Time.After(after) {
+		return a.Time, true
+	}
+
+	return time.Time{}, false
+}
+
+func (a *At) String() string {
+	return "at(" + a.Time.Format("2006-01-02T15:04:05") + ")"
+}
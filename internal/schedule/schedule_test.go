@@ -0,0 +1,127 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	testCases := map[string]struct {
+		expression string
+		kind       Kind
+		wantErr    bool
+	}{
+		"cron":                     {expression: "cron(0 16 ? * TUE *)", kind: KindCron},
+		"cron nth weekday":         {expression: "cron(0 2 ? * THU#2 *)", kind: KindCron},
+		"cron last day":            {expression: "cron(0 6 L * ? *)", kind: KindCron},
+		"cron nearest weekday":     {expression: "cron(0 6 15W * ? *)", kind: KindCron},
+		"cron step":                {expression: "cron(0/30 * * * ? *)", kind: KindCron},
+		"cron month names":         {expression: "cron(0 6 1 JAN-MAR ? 2030-2035)", kind: KindCron},
+		"rate":                     {expression: "rate(30 minutes)", kind: KindRate},
+		"rate singular":            {expression: "rate(1 day)", kind: KindRate},
+		"at":                       {expression: "at(2030-01-02T03:04:05)", kind: KindAt},
+		"missing wrapper":          {expression: "0 16 ? * TUE *", wantErr: true},
+		"unknown wrapper":          {expression: "every(5 minutes)", wantErr: true},
+		"cron five fields":         {expression: "cron(0 16 ? * TUE)", wantErr: true},
+		"cron both days":           {expression: "cron(0 16 1 * TUE *)", wantErr: true},
+		"cron neither day":         {expression: "cron(0 16 ? * ? *)", wantErr: true},
+		"cron minute out of range": {expression: "cron(60 16 ? * TUE *)", wantErr: true},
+		"cron bad month name":      {expression: "cron(0 16 1 FOO ? *)", wantErr: true},
+		"cron bad nth":             {expression: "cron(0 2 ? * THU#6 *)", wantErr: true},
+		"rate zero":                {expression: "rate(0 minutes)", wantErr: true},
+		"rate unit":                {expression: "rate(5 weeks)", wantErr: true},
+		"at format":                {expression: "at(2030-01-02 03:04)", wantErr: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			expression, err := Parse(testCase.expression)
+
+			if testCase.wantErr {
+				if err == nil {
+					t.Fatalf("expected error parsing %q", testCase.expression)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error parsing %q: %s", testCase.expression, err)
+			}
+
+			if expression.Kind() != testCase.kind {
+				t.Errorf("expected kind %q, got %q", testCase.kind, expression.Kind())
+			}
+		})
+	}
+}
+
+func TestNextN(t *testing.T) {
+	// Wednesday, 1 January 2025.
+	from := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := map[string]struct {
+		expression string
+		want       []string
+	}{
+		"every tuesday": {
+			expression: "cron(0 16 ? * TUE *)",
+			want:       []string{"2025-01-07T16:00:00Z", "2025-01-14T16:00:00Z", "2025-01-21T16:00:00Z"},
+		},
+		"second thursday": {
+			expression: "cron(0 2 ? * THU#2 *)",
+			want:       []string{"2025-01-09T02:00:00Z", "2025-02-13T02:00:00Z", "2025-03-13T02:00:00Z"},
+		},
+		"last day of month": {
+			expression: "cron(30 6 L * ? *)",
+			want:       []string{"2025-01-31T06:30:00Z", "2025-02-28T06:30:00Z", "2025-03-31T06:30:00Z"},
+		},
+		"last friday": {
+			expression: "cron(0 0 ? * 6L *)",
+			want:       []string{"2025-01-31T00:00:00Z", "2025-02-28T00:00:00Z", "2025-03-28T00:00:00Z"},
+		},
+		"nearest weekday": {
+			expression: "cron(0 0 1W * ? *)",
+			want:       []string{"2025-02-03T00:00:00Z", "2025-03-03T00:00:00Z", "2025-04-01T00:00:00Z"},
+		},
+		"wrapping day range": {
+			expression: "cron(0 12 ? * FRI-SUN 2025)",
+			want:       []string{"2025-01-03T12:00:00Z", "2025-01-04T12:00:00Z", "2025-01-05T12:00:00Z"},
+		},
+		"every thirty minutes": {
+			expression: "cron(0/30 * * * ? *)",
+			want:       []string{"2025-01-01T00:30:00Z", "2025-01-01T01:00:00Z", "2025-01-01T01:30:00Z"},
+		},
+		"past year": {
+			expression: "cron(0 0 1 1 ? 2020)",
+			want:       nil,
+		},
+		"rate": {
+			expression: "rate(2 hours)",
+			want:       []string{"2025-01-01T02:00:00Z", "2025-01-01T04:00:00Z", "2025-01-01T06:00:00Z"},
+		},
+		"at": {
+			expression: "at(2025-06-01T10:00:00)",
+			want:       []string{"2025-06-01T10:00:00Z"},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			expression, err := Parse(testCase.expression)
+			if err != nil {
+				t.Fatalf("unexpected error parsing %q: %s", testCase.expression, err)
+			}
+
+			got := NextN(expression, from, 3)
+			if len(got) != len(testCase.want) {
+				t.Fatalf("expected %d fire times, got %s", len(testCase.want), FormatTimes(got))
+			}
+
+			for i := range got {
+				if got[i].Format(time.RFC3339) != testCase.want[i] {
+					t.Errorf("fire time %d: expected %s, got %s", i, testCase.want[i], got[i].Format(time.RFC3339))
+				}
+			}
+		})
+	}
+}
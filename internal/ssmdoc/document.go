@@ -0,0 +1,253 @@
+// Package ssmdoc parses the content of SSM documents and validates the parameters
+// supplied to them.
+package ssmdoc
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"reflect"
+	"strconv"
+)
+
+// Document content formats.
+const (
+	FormatJSON = "JSON"
+	FormatYAML = "YAML"
+	FormatText = "TEXT"
+)
+
+// Parameter types supported in SSM documents.
+const (
+	ParameterTypeString     = "String"
+	ParameterTypeStringList = "StringList"
+	ParameterTypeInteger    = "Integer"
+	ParameterTypeBoolean    = "Boolean"
+	ParameterTypeMapList    = "MapList"
+	ParameterTypeStringMap  = "StringMap"
+)
+
+// Document is the part of an SSM document's content relevant to its callers.
+type Document struct {
+	SchemaVersion Version              `json:"schemaVersion"`
+	Description   string               `json:"description"`
+	Parameters    map[string]Parameter `json:"parameters"`
+	AssumeRole    string               `json:"assumeRole"`
+	MainSteps     []Step               `json:"mainSteps"`
+	RuntimeConfig map[string]any       `json:"runtimeConfig"`
+	SessionType   string               `json:"sessionType"`
+}
+
+// Version is a document's schemaVersion.  It is written as a string, but also accepted
+// as a number, as YAML documents commonly leave it unquoted.
+type Version string
+
+// UnmarshalJSON decodes a schemaVersion written as either a string or a number, keeping
+// the number as written.
+func (v *Version) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		*v = Version(s)
+		return nil
+	}
+
+	var n json.Number
+	if err := json.Unmarshal(b, &n); err != nil {
+		return fmt.Errorf("schemaVersion must be a string or a number: %w", err)
+	}
+
+	*v = Version(n)
+
+	return nil
+}
+
+// Step is a single step of a document's mainSteps.
+type Step struct {
+	Name   string `json:"name"`
+	Action string `json:"action"`
+}
+
+// Parameter is the definition of a single document parameter.
+type Parameter struct {
+	Type           string          `json:"type"`
+	Description    string          `json:"description"`
+	Default        json.RawMessage `json:"default"`
+	AllowedValues  []any           `json:"allowedValues"`
+	AllowedPattern string          `json:"allowedPattern"`
+	MinItems       *int            `json:"minItems"`
+	MaxItems       *int            `json:"maxItems"`
+	MinChars       *int            `json:"minChars"`
+	MaxChars       *int            `json:"maxChars"`
+}
+
+// Parse parses the JSON content of an SSM document.
+func Parse(content string) (*Document, error) {
+	var document Document
+
+	if err := json.Unmarshal([]byte(content), &document); err != nil {
+		return nil, fmt.Errorf("parsing document content: %w", err)
+	}
+
+	return &document, nil
+}
+
+// ParseFormat parses the content of an SSM document in the given format, JSON or YAML.
+func ParseFormat(content, format string) (*Document, error) {
+	switch format {
+	case FormatJSON:
+		return Parse(content)
+	case FormatYAML:
+		value, err := decode(content)
+		if err != nil {
+			return nil, err
+		}
+
+		b, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("parsing document content: %w", err)
+		}
+
+		return Parse(string(b))
+	default:
+		return nil, fmt.Errorf("unsupported document format %q", format)
+	}
+}
+
+// Equal reports whether two document contents hold the same data, regardless of
+// whether they are written as JSON or YAML, their layout or the order of their keys.
+func Equal(a, b string) bool {
+	x, err := decode(a)
+	if err != nil {
+		return false
+	}
+
+	y, err := decode(b)
+	if err != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(x, y)
+}
+
+// decode decodes JSON or YAML content, as JSON is a subset of YAML.  An unquoted
+// schemaVersion is decoded as written, so that 1.0 is not read as the number 1.
+func decode(content string) (any, error) {
+	var node yaml.Node
+
+	if err := yaml.Unmarshal([]byte(content), &node); err != nil {
+		return nil, fmt.Errorf("parsing document content: %w", err)
+	}
+
+	if len(node.Content) == 1 && node.Content[0].Kind == yaml.MappingNode {
+		mapping := node.Content[0].Content
+
+		for i := 0; i+1 < len(mapping); i += 2 {
+			if key, value := mapping[i], mapping[i+1]; key.Value == "schemaVersion" && value.Kind == yaml.ScalarNode && (value.Tag == "!!float" || value.Tag == "!!int") {
+				value.Tag = "!!str"
+			}
+		}
+	}
+
+	var value any
+
+	if err := node.Decode(&value); err != nil {
+		return nil, fmt.Errorf("parsing document content: %w", err)
+	}
+
+	if _, ok := value.(map[string]any); !ok {
+		return nil, fmt.Errorf("parsing document content: expected an object, got %T", value)
+	}
+
+	return value, nil
+}
+
+// HasDefault reports whether the parameter declares a default value, making it optional.
+func (p Parameter) HasDefault() bool {
+	return len(p.Default) > 0
+}
+
+// AllowedValueStrings returns the allowed values as strings, as they are supplied through the API.
+func (p Parameter) AllowedValueStrings() []string {
+	values := make([]string, len(p.AllowedValues))
+
+	for i, v := range p.AllowedValues {
+		values[i] = fmt.Sprint(v)
+	}
+
+	return values
+}
+
+// DefaultValues returns the default value of the parameter in the form parameters are
+// passed through the API, a list of strings.  List elements and maps that are not strings
+// are encoded as JSON.  The result is nil when the parameter has no default.
+func (p Parameter) DefaultValues() ([]string, error) {
+	if !p.HasDefault() {
+		return nil, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(p.Default))
+	decoder.UseNumber()
+
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, fmt.Errorf("decoding default value: %w", err)
+	}
+
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case []any:
+		values := make([]string, len(v))
+
+		for i, element := range v {
+			s, err := defaultString(element)
+			if err != nil {
+				return nil, err
+			}
+
+			values[i] = s
+		}
+
+		return values, nil
+	default:
+		s, err := defaultString(v)
+		if err != nil {
+			return nil, err
+		}
+
+		return []string{s}, nil
+	}
+}
+
+// Defaults returns the default values of all parameters declaring a default.
+func (d *Document) Defaults() (map[string][]string, error) {
+	defaults := make(map[string][]string)
+
+	for name, parameter := range d.Parameters {
+		values, err := parameter.DefaultValues()
+		if err != nil {
+			return nil, fmt.Errorf("parameter %q: %w", name, err)
+		}
+
+		if values != nil {
+			defaults[name] = values
+		}
+	}
+
+	return defaults, nil
+}
+
+func defaultString(value any) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	default:
+		b, err := json.Marshal(v)
+		return string(b), err
+	}
+}
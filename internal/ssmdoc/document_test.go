@@ -0,0 +1,186 @@
+package ssmdoc
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	document, err := Parse(testDocument)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if document.SchemaVersion != "0.3" {
+		t.Errorf("expected schema version 0.3, got %q", document.SchemaVersion)
+	}
+
+	if document.Parameters["InstanceId"].HasDefault() {
+		t.Error("expected InstanceId to have no default")
+	}
+
+	if !document.Parameters["AutomationAssumeRole"].HasDefault() {
+		t.Error("expected AutomationAssumeRole to have a default")
+	}
+
+	if _, err := Parse("schemaVersion: '2.2'"); err == nil {
+		t.Error("expected error parsing non-JSON content")
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	document, err := ParseFormat(`
+schemaVersion: '2.2'
+parameters:
+  Message:
+    type: String
+    default: hello
+mainSteps:
+  - action: aws:runShellScript
+    name: echo
+    inputs:
+      runCommand:
+        - echo {{ Message }}
+`, FormatYAML)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if document.SchemaVersion != "2.2" {
+		t.Errorf("expected schema version 2.2, got %q", document.SchemaVersion)
+	}
+
+	values, err := document.Parameters["Message"].DefaultValues()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(values, []string{"hello"}) {
+		t.Errorf("expected default [hello], got %v", values)
+	}
+
+	if len(document.MainSteps) != 1 || document.MainSteps[0].Name != "echo" {
+		t.Errorf("expected a single echo step, got %v", document.MainSteps)
+	}
+
+	testCases := map[string]struct {
+		content string
+		format  string
+		want    Version
+	}{
+		"unquoted YAML": {
+			content: "schemaVersion: 2.2\n",
+			format:  FormatYAML,
+			want:    "2.2",
+		},
+		"unquoted YAML trailing zero": {
+			content: "schemaVersion: 1.0\n",
+			format:  FormatYAML,
+			want:    "1.0",
+		},
+		"JSON number": {
+			content: `{"schemaVersion": 0.3}`,
+			format:  FormatJSON,
+			want:    "0.3",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			document, err := ParseFormat(testCase.content, testCase.format)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if document.SchemaVersion != testCase.want {
+				t.Errorf("expected schema version %s, got %q", testCase.want, document.SchemaVersion)
+			}
+		})
+	}
+
+	if _, err := ParseFormat("- not an object", FormatYAML); err == nil {
+		t.Error("expected error parsing a YAML list")
+	}
+
+	if _, err := ParseFormat("BEGIN:VCALENDAR", FormatText); err == nil {
+		t.Error("expected error parsing TEXT content")
+	}
+}
+
+func TestEqual(t *testing.T) {
+	testCases := map[string]struct {
+		a, b string
+		want bool
+	}{
+		"identical": {
+			a:    `{"schemaVersion": "2.2"}`,
+			b:    `{"schemaVersion": "2.2"}`,
+			want: true,
+		},
+		"layout and key order": {
+			a:    `{"schemaVersion": "2.2", "description": "x"}`,
+			b:    "{\n  \"description\": \"x\",\n  \"schemaVersion\": \"2.2\"\n}",
+			want: true,
+		},
+		"JSON and YAML": {
+			a:    `{"schemaVersion": "2.2", "mainSteps": [{"name": "a"}]}`,
+			b:    "schemaVersion: '2.2'\nmainSteps:\n  - name: a\n",
+			want: true,
+		},
+		"different values": {
+			a: `{"schemaVersion": "2.2"}`,
+			b: `{"schemaVersion": "2.0"}`,
+		},
+		"invalid content": {
+			a: `{"schemaVersion": "2.2"`,
+			b: `{"schemaVersion": "2.2"`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := Equal(testCase.a, testCase.b); got != testCase.want {
+				t.Errorf("expected %t, got %t", testCase.want, got)
+			}
+		})
+	}
+}
+
+func TestDocument_Defaults(t *testing.T) {
+	document, err := Parse(`{
+  "parameters": {
+    "Required": {"type": "String"},
+    "Empty": {"type": "String", "default": ""},
+    "Name": {"type": "String", "default": "example"},
+    "Retries": {"type": "Integer", "default": 3},
+    "Force": {"type": "Boolean", "default": false},
+    "Commands": {"type": "StringList", "default": ["echo a", "echo b"]},
+    "None": {"type": "StringList", "default": []},
+    "Tags": {"type": "StringMap", "default": {"Name": "example"}},
+    "Maps": {"type": "MapList", "default": [{"Key": "a"}]}
+  }
+}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := document.Defaults()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string][]string{
+		"Empty":    {""},
+		"Name":     {"example"},
+		"Retries":  {"3"},
+		"Force":    {"false"},
+		"Commands": {"echo a", "echo b"},
+		"None":     {},
+		"Tags":     {`{"Name":"example"}`},
+		"Maps":     {`{"Key":"a"}`},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
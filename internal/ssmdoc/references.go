@@ -0,0 +1,30 @@
+package ssmdoc
+
+import (
+	"regexp"
+)
+
+// parameterReference matches {{ssm:name}} and {{ssm-secure:name}} references, where
+// name may carry a :version or :label selector.
+var parameterReference = regexp.MustCompile(`\{\{\s*(ssm|ssm-secure):([^\s{}]+)\s*\}\}`)
+
+// Reference is a reference from a parameter value to a Parameter Store parameter,
+// resolved by SSM when the document runs.
+type Reference struct {
+	Name   string
+	Secure bool
+}
+
+// References returns the Parameter Store references in a parameter value.
+func References(value string) []Reference {
+	var references []Reference
+
+	for _, match := range parameterReference.FindAllStringSubmatch(value, -1) {
+		references = append(references, Reference{
+			Name:   match[2],
+			Secure: match[1] == "ssm-secure",
+		})
+	}
+
+	return references
+}
@@ -0,0 +1,31 @@
+package ssmdoc
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestReferences(t *testing.T) {
+	testCases := map[string]struct {
+		value string
+		want  []Reference
+	}{
+		"none":     {value: "echo hello"},
+		"ssm":      {value: "{{ssm:/app/endpoint}}", want: []Reference{{Name: "/app/endpoint"}}},
+		"secure":   {value: "{{ ssm-secure:/app/password }}", want: []Reference{{Name: "/app/password", Secure: true}}},
+		"selector": {value: "{{ssm:/app/endpoint:3}}", want: []Reference{{Name: "/app/endpoint:3"}}},
+		"embedded": {
+			value: "connect {{ssm:/app/endpoint}} --password {{ssm-secure:/app/password}}",
+			want:  []Reference{{Name: "/app/endpoint"}, {Name: "/app/password", Secure: true}},
+		},
+		"automation variable": {value: "{{automation:EXECUTION_ID}}"},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := References(testCase.value); !slices.Equal(got, testCase.want) {
+				t.Errorf("expected %v, got %v", testCase.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package ssmdoc
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// Document types with a content schema validated by Validate.
+const (
+	DocumentTypeCommand    = "Command"
+	DocumentTypeAutomation = "Automation"
+	DocumentTypeSession    = "Session"
+)
+
+// schemaVersions are the schema versions accepted for each document type.
+var schemaVersions = map[string][]string{
+	DocumentTypeCommand:    {"1.2", "2.0", "2.2"},
+	DocumentTypeAutomation: {"0.3"},
+	DocumentTypeSession:    {"1.0"},
+}
+
+var parameterTypes = []string{
+	ParameterTypeString,
+	ParameterTypeStringList,
+	ParameterTypeInteger,
+	ParameterTypeBoolean,
+	ParameterTypeMapList,
+	ParameterTypeStringMap,
+}
+
+// Validate checks the structure of the document against the schema of the document
+// type, returning every problem found.  Document types without a known schema only
+// require a schema version.
+func (d *Document) Validate(documentType string) []error {
+	var errs []error
+
+	if d.SchemaVersion == "" {
+		return append(errs, fmt.Errorf("schemaVersion is required"))
+	}
+
+	versions, ok := schemaVersions[documentType]
+	if !ok {
+		return errs
+	}
+
+	if !slices.Contains(versions, string(d.SchemaVersion)) {
+		errs = append(errs, fmt.Errorf("schemaVersion %q is not supported for %s documents, expected one of: %s", d.SchemaVersion, documentType, strings.Join(versions, ", ")))
+		return errs
+	}
+
+	switch {
+	case documentType == DocumentTypeCommand && d.SchemaVersion == "1.2":
+		if len(d.RuntimeConfig) == 0 {
+			errs = append(errs, fmt.Errorf("runtimeConfig is required for schemaVersion 1.2"))
+		}
+	case documentType == DocumentTypeSession:
+		if d.SessionType == "" {
+			errs = append(errs, fmt.Errorf("sessionType is required"))
+		}
+	default:
+		errs = append(errs, d.validateSteps()...)
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(d.Parameters)) {
+		if err := validateParameterType(documentType, d.Parameters[name].Type); err != nil {
+			errs = append(errs, fmt.Errorf("parameter %q: %w", name, err))
+		}
+	}
+
+	return errs
+}
+
+func (d *Document) validateSteps() []error {
+	var errs []error
+
+	if len(d.MainSteps) == 0 {
+		return append(errs, fmt.Errorf("mainSteps must contain at least one step"))
+	}
+
+	names := make(map[string]bool, len(d.MainSteps))
+
+	for i, step := range d.MainSteps {
+		if step.Name == "" {
+			errs = append(errs, fmt.Errorf("mainSteps[%d]: name is required", i))
+		} else if names[step.Name] {
+			errs = append(errs, fmt.Errorf("mainSteps[%d]: duplicate step name %q", i, step.Name))
+		}
+
+		if step.Action == "" {
+			errs = append(errs, fmt.Errorf("mainSteps[%d]: action is required", i))
+		}
+
+		names[step.Name] = true
+	}
+
+	return errs
+}
+
+func validateParameterType(documentType, parameterType string) error {
+	if parameterType == "" {
+		return fmt.Errorf("type is required")
+	}
+
+	if slices.Contains(parameterTypes, parameterType) {
+		return nil
+	}
+
+	// Automation runbooks also accept AWS resource types such as AWS::EC2::Instance::Id.
+	if documentType == DocumentTypeAutomation && (strings.HasPrefix(parameterType, "AWS::") || strings.HasPrefix(parameterType, "List<AWS::")) {
+		return nil
+	}
+
+	return fmt.Errorf("unsupported type %q", parameterType)
+}
@@ -0,0 +1,95 @@
+package ssmdoc
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDocument_Validate(t *testing.T) {
+	testCases := map[string]struct {
+		documentType string
+		content      string
+		want         []string
+	}{
+		"valid command": {
+			documentType: DocumentTypeCommand,
+			content:      `{"schemaVersion": "2.2", "parameters": {"Message": {"type": "String"}}, "mainSteps": [{"name": "echo", "action": "aws:runShellScript"}]}`,
+		},
+		"valid legacy command": {
+			documentType: DocumentTypeCommand,
+			content:      `{"schemaVersion": "1.2", "runtimeConfig": {"aws:runShellScript": {}}}`,
+		},
+		"valid automation": {
+			documentType: DocumentTypeAutomation,
+			content:      `{"schemaVersion": "0.3", "parameters": {"InstanceId": {"type": "AWS::EC2::Instance::Id"}}, "mainSteps": [{"name": "stop", "action": "aws:changeInstanceState"}]}`,
+		},
+		"valid session": {
+			documentType: DocumentTypeSession,
+			content:      `{"schemaVersion": "1.0", "sessionType": "Standard_Stream"}`,
+		},
+		"unknown document type": {
+			documentType: "ChangeCalendar",
+			content:      `{"schemaVersion": "2.0"}`,
+		},
+		"missing schema version": {
+			documentType: DocumentTypeCommand,
+			content:      `{"mainSteps": []}`,
+			want:         []string{"schemaVersion is required"},
+		},
+		"unsupported schema version": {
+			documentType: DocumentTypeAutomation,
+			content:      `{"schemaVersion": "2.2"}`,
+			want:         []string{`schemaVersion "2.2" is not supported for Automation documents, expected one of: 0.3`},
+		},
+		"missing runtime config": {
+			documentType: DocumentTypeCommand,
+			content:      `{"schemaVersion": "1.2"}`,
+			want:         []string{"runtimeConfig is required for schemaVersion 1.2"},
+		},
+		"missing session type": {
+			documentType: DocumentTypeSession,
+			content:      `{"schemaVersion": "1.0"}`,
+			want:         []string{"sessionType is required"},
+		},
+		"missing steps": {
+			documentType: DocumentTypeCommand,
+			content:      `{"schemaVersion": "2.2"}`,
+			want:         []string{"mainSteps must contain at least one step"},
+		},
+		"invalid steps": {
+			documentType: DocumentTypeAutomation,
+			content:      `{"schemaVersion": "0.3", "mainSteps": [{"name": "a", "action": "aws:sleep"}, {"name": "a"}, {"action": "aws:sleep"}]}`,
+			want: []string{
+				`mainSteps[1]: duplicate step name "a"`,
+				"mainSteps[1]: action is required",
+				"mainSteps[2]: name is required",
+			},
+		},
+		"invalid parameter types": {
+			documentType: DocumentTypeCommand,
+			content:      `{"schemaVersion": "2.2", "parameters": {"B": {"type": "AWS::EC2::Instance::Id"}, "A": {}}, "mainSteps": [{"name": "a", "action": "aws:runShellScript"}]}`,
+			want: []string{
+				`parameter "A": type is required`,
+				`parameter "B": unsupported type "AWS::EC2::Instance::Id"`,
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			document, err := Parse(testCase.content)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got []string
+			for _, err := range document.Validate(testCase.documentType) {
+				got = append(got, err.Error())
+			}
+
+			if !slices.Equal(got, testCase.want) {
+				t.Errorf("expected %q, got %q", testCase.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package ssmdoc
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ParameterError is a problem with a single supplied parameter.  Parameter is empty when
+// the problem concerns the parameters as a whole, such as a missing required parameter.
+type ParameterError struct {
+	Parameter string
+	Message   string
+}
+
+func (e ParameterError) Error() string {
+	if e.Parameter == "" {
+		return e.Message
+	}
+
+	return fmt.Sprintf("parameter %q: %s", e.Parameter, e.Message)
+}
+
+// ValidateParameters checks supplied parameter values against the document's parameter
+// definitions: parameter names, required parameters, types, allowed values, allowed
+// patterns and size constraints.  A parameter with no values counts as not supplied.
+//
+// Parameters named in present are treated as supplied without checking their values,
+// e.g. values that are not known yet or a parameter filled in from targets.  Values
+// containing {{ }} references are resolved by SSM at runtime and are not checked.
+func (d *Document) ValidateParameters(values map[string][]string, present ...string) []ParameterError {
+	var errors []ParameterError
+
+	for _, name := range slices.Sorted(maps.Keys(values)) {
+		parameter, ok := d.Parameters[name]
+		if !ok {
+			errors = append(errors, ParameterError{
+				Parameter: name,
+				Message:   fmt.Sprintf("not a parameter of this document, expected one of: %s", strings.Join(slices.Sorted(maps.Keys(d.Parameters)), ", ")),
+			})
+			continue
+		}
+
+		if slices.Contains(present, name) {
+			continue
+		}
+
+		for _, message := range parameter.validate(values[name]) {
+			errors = append(errors, ParameterError{Parameter: name, Message: message})
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(d.Parameters)) {
+		if d.Parameters[name].HasDefault() || len(values[name]) > 0 || slices.Contains(present, name) {
+			continue
+		}
+
+		errors = append(errors, ParameterError{
+			Message: fmt.Sprintf("missing required parameter %q", name),
+		})
+	}
+
+	return errors
+}
+
+func (p Parameter) validate(values []string) []string {
+	var messages []string
+
+	switch p.Type {
+	case ParameterTypeString, ParameterTypeInteger, ParameterTypeBoolean, ParameterTypeStringMap:
+		if len(values) > 1 {
+			return []string{fmt.Sprintf("a %s parameter takes a single value, got %d", p.Type, len(values))}
+		}
+	case ParameterTypeStringList, ParameterTypeMapList:
+		if p.MinItems != nil && len(values) < *p.MinItems {
+			messages = append(messages, fmt.Sprintf("expected at least %d values, got %d", *p.MinItems, len(values)))
+		}
+
+		if p.MaxItems != nil && len(values) > *p.MaxItems {
+			messages = append(messages, fmt.Sprintf("expected at most %d values, got %d", *p.MaxItems, len(values)))
+		}
+	}
+
+	var pattern *regexp.Regexp
+	if p.AllowedPattern != "" {
+		// Patterns using syntax Go does not support, e.g. lookaheads, are left to SSM.
+		pattern, _ = regexp.Compile(p.AllowedPattern)
+	}
+
+	allowedValues := p.AllowedValueStrings()
+
+	for _, value := range values {
+		if isReference(value) {
+			continue
+		}
+
+		if message := p.validateType(value); message != "" {
+			messages = append(messages, message)
+			continue
+		}
+
+		if len(allowedValues) > 0 && !slices.Contains(allowedValues, value) {
+			messages = append(messages, fmt.Sprintf("value %q is not allowed, expected one of: %s", value, strings.Join(allowedValues, ", ")))
+		}
+
+		if pattern != nil && !pattern.MatchString(value) {
+			messages = append(messages, fmt.Sprintf("value %q does not match the allowed pattern %s", value, p.AllowedPattern))
+		}
+
+		if n := utf8.RuneCountInString(value); p.MinChars != nil && n < *p.MinChars {
+			messages = append(messages, fmt.Sprintf("value %q is shorter than %d characters", value, *p.MinChars))
+		} else if p.MaxChars != nil && n > *p.MaxChars {
+			messages = append(messages, fmt.Sprintf("value %q is longer than %d characters", value, *p.MaxChars))
+		}
+	}
+
+	return messages
+}
+
+func (p Parameter) validateType(value string) string {
+	switch p.Type {
+	case ParameterTypeInteger:
+		if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+			return fmt.Sprintf("value %q is not an integer", value)
+		}
+	case ParameterTypeBoolean:
+		if value != "true" && value != "false" {
+			return fmt.Sprintf("value %q is not a boolean, expected true or false", value)
+		}
+	case ParameterTypeStringMap, ParameterTypeMapList:
+		var m map[string]any
+		if err := json.Unmarshal([]byte(value), &m); err != nil {
+			return fmt.Sprintf("value %q is not a JSON object", value)
+		}
+	}
+
+	return ""
+}
+
+// isReference reports whether a value contains a {{ }} reference, such as
+// {{ssm:parameter-name}} or {{automation:EXECUTION_ID}}, resolved by SSM at runtime.
+func isReference(value string) bool {
+	start := strings.Index(value, "{{")
+	return start >= 0 && strings.Contains(value[start:], "}}")
+}
@@ -0,0 +1,136 @@
+package ssmdoc
+
+import (
+	"slices"
+	"testing"
+)
+
+const testDocument = `{
+  "schemaVersion": "0.3",
+  "description": "Test runbook",
+  "parameters": {
+    "InstanceId": {
+      "type": "String",
+      "allowedPattern": "^i-[a-z0-9]{8,17}$"
+    },
+    "AutomationAssumeRole": {
+      "type": "String",
+      "default": ""
+    },
+    "Action": {
+      "type": "String",
+      "default": "Stop",
+      "allowedValues": ["Start", "Stop"]
+    },
+    "Retries": {
+      "type": "Integer",
+      "default": 3
+    },
+    "Force": {
+      "type": "Boolean",
+      "default": false
+    },
+    "Commands": {
+      "type": "StringList",
+      "default": [],
+      "maxItems": 2
+    },
+    "Tags": {
+      "type": "StringMap",
+      "default": {}
+    },
+    "Comment": {
+      "type": "String",
+      "default": "",
+      "maxChars": 5
+    }
+  }
+}`
+
+func TestDocument_ValidateParameters(t *testing.T) {
+	document, err := Parse(testDocument)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := map[string]struct {
+		values  map[string][]string
+		present []string
+		want    []ParameterError
+	}{
+		"valid": {
+			values: map[string][]string{
+				"InstanceId": {"i-0123456789abcdef0"},
+				"Action":     {"Start"},
+				"Retries":    {"5"},
+				"Force":      {"true"},
+				"Commands":   {"a", "b"},
+				"Tags":       {`{"Name":"example"}`},
+			},
+		},
+		"missing required": {
+			values: map[string][]string{"Action": {"Start"}},
+			want:   []ParameterError{{Message: `missing required parameter "InstanceId"`}},
+		},
+		"empty required": {
+			values: map[string][]string{"InstanceId": {}},
+			want:   []ParameterError{{Message: `missing required parameter "InstanceId"`}},
+		},
+		"required present": {
+			values:  map[string][]string{},
+			present: []string{"InstanceId"},
+		},
+		"unknown parameter": {
+			values: map[string][]string{"InstanceId": {"i-0123456789abcdef0"}, "instanceId": {"x"}},
+			want: []ParameterError{{
+				Parameter: "instanceId",
+				Message:   "not a parameter of this document, expected one of: Action, AutomationAssumeRole, Commands, Comment, Force, InstanceId, Retries, Tags",
+			}},
+		},
+		"not allowed value": {
+			values: map[string][]string{"InstanceId": {"i-0123456789abcdef0"}, "Action": {"Reboot"}},
+			want:   []ParameterError{{Parameter: "Action", Message: `value "Reboot" is not allowed, expected one of: Start, Stop`}},
+		},
+		"pattern mismatch": {
+			values: map[string][]string{"InstanceId": {"instance"}},
+			want:   []ParameterError{{Parameter: "InstanceId", Message: `value "instance" does not match the allowed pattern ^i-[a-z0-9]{8,17}$`}},
+		},
+		"reference": {
+			values: map[string][]string{"InstanceId": {"{{ssm:/example/instance}}"}},
+		},
+		"integer": {
+			values: map[string][]string{"InstanceId": {"i-0123456789abcdef0"}, "Retries": {"three"}},
+			want:   []ParameterError{{Parameter: "Retries", Message: `value "three" is not an integer`}},
+		},
+		"boolean": {
+			values: map[string][]string{"InstanceId": {"i-0123456789abcdef0"}, "Force": {"yes"}},
+			want:   []ParameterError{{Parameter: "Force", Message: `value "yes" is not a boolean, expected true or false`}},
+		},
+		"string map": {
+			values: map[string][]string{"InstanceId": {"i-0123456789abcdef0"}, "Tags": {"Name=example"}},
+			want:   []ParameterError{{Parameter: "Tags", Message: `value "Name=example" is not a JSON object`}},
+		},
+		"single value": {
+			values: map[string][]string{"InstanceId": {"i-0123456789abcdef0", "i-0123456789abcdef1"}},
+			want:   []ParameterError{{Parameter: "InstanceId", Message: "a String parameter takes a single value, got 2"}},
+		},
+		"max items": {
+			values: map[string][]string{"InstanceId": {"i-0123456789abcdef0"}, "Commands": {"a", "b", "c"}},
+			want:   []ParameterError{{Parameter: "Commands", Message: "expected at most 2 values, got 3"}},
+		},
+		"max chars": {
+			values: map[string][]string{"InstanceId": {"i-0123456789abcdef0"}, "Comment": {"too long"}},
+			want:   []ParameterError{{Parameter: "Comment", Message: `value "too long" is longer than 5 characters`}},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := document.ValidateParameters(testCase.values, testCase.present...)
+
+			if !slices.Equal(got, testCase.want) {
+				t.Errorf("expected %v, got %v", testCase.want, got)
+			}
+		})
+	}
+}